@@ -135,11 +135,11 @@ func (c *CIDR) Split(mask int) (CIDRs, error) {
 }
 
 func (c *CIDR) Net() *net.IPNet {
-	return &net.IPNet{c.IP.IP, net.IPMask(MaskToIP(c.Mask, c.Ver).IP)}
+	return &net.IPNet{IP: c.IP.IP, Mask: net.IPMask(MaskToIP(c.Mask, c.Ver).IP)}
 }
 
 func (c *CIDR) NetWithMask(mask int) *net.IPNet {
-	return &net.IPNet{c.IP.IP, net.IPMask(MaskToIP(mask, c.Ver).IP)}
+	return &net.IPNet{IP: c.IP.IP, Mask: net.IPMask(MaskToIP(mask, c.Ver).IP)}
 }
 
 func (c *CIDR) IPMask() net.IPMask {
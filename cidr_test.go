@@ -86,3 +86,20 @@ func TestCIDRs_Coalesce(t *testing.T) {
 		println(i.String())
 	}
 }
+
+func TestParseCIDRTarget(t *testing.T) {
+	// ParseCIDR 经过 ParseHost 清洗, 接受带scheme, 端口, 路径与全角字符的目标
+	testCases := map[string]string{
+		"http://192.168.1.1:8080/login": "192.168.1.1/32",
+		"192.168.1.1:80":                "192.168.1.1/32",
+		"１９２．１６８．１．１/24":                "192.168.1.1/24",
+		" 10.0.0.1/8,":                  "10.0.0.1/8",
+		"https://[::1]:443/":            "::1/128",
+	}
+	for input, want := range testCases {
+		c := ParseCIDR(input)
+		if c == nil || c.String() != want {
+			t.Errorf("ParseCIDR(%q) = %v, want %s", input, c, want)
+		}
+	}
+}
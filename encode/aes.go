@@ -0,0 +1,232 @@
+package encode
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidPadding = errors.New("invalid pkcs7 padding")
+
+// PKCS7Pad 按blockSize补齐, 数据恰好对齐时补一个完整的块
+func PKCS7Pad(data []byte, blockSize int) []byte {
+	n := blockSize - len(data)%blockSize
+	return append(append(make([]byte, 0, len(data)+n), data...), bytes.Repeat([]byte{byte(n)}, n)...)
+}
+
+// PKCS7Unpad 去除PKCS7补齐, 补齐不合法时返回 ErrInvalidPadding, 通常意味着key或iv错误
+func PKCS7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, ErrInvalidPadding
+	}
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize || n > len(data) {
+		return nil, ErrInvalidPadding
+	}
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, ErrInvalidPadding
+		}
+	}
+	return data[:len(data)-n], nil
+}
+
+// AesCBCEncrypt 使用PKCS7补齐后以CBC模式加密
+// iv为nil时随机生成并拼接在密文之前, 与shiro rememberMe等常见格式一致
+func AesCBCEncrypt(data, key, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cbcEncrypt(block, data, iv)
+}
+
+// AesCBCDecrypt 以CBC模式解密并去除PKCS7补齐, iv为nil时取密文的前16字节作为iv
+func AesCBCDecrypt(data, key, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cbcDecrypt(block, data, iv)
+}
+
+// AesECBEncrypt 使用PKCS7补齐后以ECB模式加密, ECB不安全, 仅用于兼容遗留系统
+func AesECBEncrypt(data, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return ecbEncrypt(block, data), nil
+}
+
+func AesECBDecrypt(data, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return ecbDecrypt(block, data)
+}
+
+// AesGCMEncrypt 以GCM模式加密, 输出为 密文||tag, nonce为nil时随机生成12字节并拼接在输出之前
+func AesGCMEncrypt(data, key, nonce []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if nonce == nil {
+		nonce = make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		return gcm.Seal(nonce, nonce, data, nil), nil
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid gcm nonce size %d", len(nonce))
+	}
+	return gcm.Seal(nil, nonce, data, nil), nil
+}
+
+// AesGCMDecrypt 以GCM模式解密并校验tag, nonce为nil时取输入的前12字节作为nonce
+func AesGCMDecrypt(data, key, nonce []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if nonce == nil {
+		if len(data) < gcm.NonceSize() {
+			return nil, fmt.Errorf("ciphertext too short")
+		}
+		nonce, data = data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid gcm nonce size %d", len(nonce))
+	}
+	return gcm.Open(nil, nonce, data, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func cbcEncrypt(block cipher.Block, data, iv []byte) ([]byte, error) {
+	bs := block.BlockSize()
+	data = PKCS7Pad(data, bs)
+	var out []byte
+	if iv == nil {
+		out = make([]byte, bs+len(data))
+		iv = out[:bs]
+		if _, err := rand.Read(iv); err != nil {
+			return nil, err
+		}
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(out[bs:], data)
+		return out, nil
+	}
+	if len(iv) != bs {
+		return nil, fmt.Errorf("invalid iv size %d", len(iv))
+	}
+	out = make([]byte, len(data))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out, data)
+	return out, nil
+}
+
+func cbcDecrypt(block cipher.Block, data, iv []byte) ([]byte, error) {
+	bs := block.BlockSize()
+	if iv == nil {
+		if len(data) < bs {
+			return nil, fmt.Errorf("ciphertext too short")
+		}
+		iv, data = data[:bs], data[bs:]
+	}
+	if len(iv) != bs {
+		return nil, fmt.Errorf("invalid iv size %d", len(iv))
+	}
+	if len(data) == 0 || len(data)%bs != 0 {
+		return nil, fmt.Errorf("ciphertext is not a multiple of the block size")
+	}
+	out := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+	return PKCS7Unpad(out, bs)
+}
+
+func ecbEncrypt(block cipher.Block, data []byte) []byte {
+	bs := block.BlockSize()
+	data = PKCS7Pad(data, bs)
+	out := make([]byte, len(data))
+	for i := 0; i < len(data); i += bs {
+		block.Encrypt(out[i:i+bs], data[i:i+bs])
+	}
+	return out
+}
+
+func ecbDecrypt(block cipher.Block, data []byte) ([]byte, error) {
+	bs := block.BlockSize()
+	if len(data) == 0 || len(data)%bs != 0 {
+		return nil, fmt.Errorf("ciphertext is not a multiple of the block size")
+	}
+	out := make([]byte, len(data))
+	for i := 0; i < len(data); i += bs {
+		block.Decrypt(out[i:i+bs], data[i:i+bs])
+	}
+	return PKCS7Unpad(out, bs)
+}
+
+// registerCipherDSL 注册 name-en 与 name-de 两个操作符, 参数为 key[,iv], 格式见 ParseKey, 省略iv时iv拼接在密文之前
+// name 本身作为 name-de 的别名, 便于书写解密流程, 如 b64de|aes-cbc:key,iv
+func registerCipherDSL(name string, encrypt, decrypt func(data, key, iv []byte) ([]byte, error)) {
+	parse := func(op string, args []string) (key, iv []byte, err error) {
+		keys, err := parseKeyList(args)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", op, err)
+		}
+		if len(keys) == 0 || len(keys) > 2 {
+			return nil, nil, fmt.Errorf("%s: expect key[,iv]", op)
+		}
+		if len(keys) == 2 {
+			iv = keys[1]
+		}
+		return keys[0], iv, nil
+	}
+	RegisterDSL(name+"-en", func(data []byte, args ...string) ([]byte, error) {
+		key, iv, err := parse(name+"-en", args)
+		if err != nil {
+			return nil, err
+		}
+		bs, err := encrypt(data, key, iv)
+		if err != nil {
+			return nil, fmt.Errorf("%s-en: %v", name, err)
+		}
+		return bs, nil
+	})
+	de := func(data []byte, args ...string) ([]byte, error) {
+		key, iv, err := parse(name+"-de", args)
+		if err != nil {
+			return nil, err
+		}
+		bs, err := decrypt(data, key, iv)
+		if err != nil {
+			return nil, fmt.Errorf("%s-de: %v", name, err)
+		}
+		return bs, nil
+	}
+	RegisterDSL(name+"-de", de)
+	RegisterDSL(name, de)
+	registerDSLPair(name+"-de", name+"-en", true)
+	RegisterDSLInverse(name, name+"-en", true)
+}
+
+func init() {
+	registerCipherDSL("aes-cbc", AesCBCEncrypt, AesCBCDecrypt)
+	registerCipherDSL("aes-gcm", AesGCMEncrypt, AesGCMDecrypt)
+	registerCipherDSL("aes-ecb", func(data, key, iv []byte) ([]byte, error) {
+		return AesECBEncrypt(data, key)
+	}, func(data, key, iv []byte) ([]byte, error) {
+		return AesECBDecrypt(data, key)
+	})
+}
@@ -0,0 +1,164 @@
+package encode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AutoDecodeMaxDepth AutoDecode 最多解码的层数, 避免异常输入导致无限循环
+var AutoDecodeMaxDepth = 16
+
+// AutoDecodeMaxSize AutoDecode 每一层解压后的最大字节数, 超过时不再解压该层, 防止压缩炸弹
+var AutoDecodeMaxSize int64 = 64 << 20
+
+type autoDecoder struct {
+	name   string // 对应的DSL操作符, 解码链可以直接拼接为DSL重放
+	match  func(data []byte) bool
+	decode func(data []byte) ([]byte, error)
+}
+
+// 按优先级排列, 有magic的格式在前, 文本编码在后, hex需要在base64之前, 因为hex字符串同时是合法的base64
+var autoDecoders = []autoDecoder{
+	{"gunzip", func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte{0x1f, 0x8b, 0x08})
+	}, limitedDecoder(GzipDecompressLimited)},
+	{"unzstd", func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte{0x28, 0xb5, 0x2f, 0xfd})
+	}, limitedDecoder(ZstdDecompressLimited)},
+	{"unzlib", isZlibHeader, limitedDecoder(ZlibDecompressLimited)},
+	{"bunzip2", isBzip2Header, limitedDecoder(Bzip2DecompressLimited)},
+	{"unxz", func(data []byte) bool {
+		return bytes.HasPrefix(data, xzMagic)
+	}, limitedDecoder(XzDecompressLimited)},
+	{"utf16de", looksLikeUTF16, UTF16Decode},
+	{"unhex", func(data []byte) bool {
+		return len(data) >= 8 && len(data)%2 == 0 && isCharset(data, "0123456789abcdefABCDEF")
+	}, textDecoder(func(data []byte) ([]byte, error) {
+		return HexDecodeE(string(data))
+	})},
+	{"b64de", func(data []byte) bool {
+		return len(data) >= 8 && isCharset(data, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/-_=")
+	}, textDecoder(func(data []byte) ([]byte, error) {
+		return Base64DecodeE(string(data))
+	})},
+	{"inflate", func(data []byte) bool {
+		return len(data) >= 4 && !looksLikeText(data)
+	}, textDecoder(limitedDecoder(DeflateDecompressLimited))},
+}
+
+func limitedDecoder(fn func([]byte, int64) ([]byte, error)) func([]byte) ([]byte, error) {
+	return func(data []byte) ([]byte, error) {
+		return fn(data, AutoDecodeMaxSize)
+	}
+}
+
+// textDecoder 只接受解码结果为可读文本, UTF-16或已知压缩格式的情况, 避免把恰好符合字符集的普通字符串(如md5)解码为乱码
+func textDecoder(fn func([]byte) ([]byte, error)) func([]byte) ([]byte, error) {
+	return func(data []byte) ([]byte, error) {
+		out, err := fn(data)
+		if err != nil {
+			return nil, err
+		}
+		if !looksLikeText(out) && !hasKnownMagic(out) && !looksLikeUTF16(out) {
+			return nil, errNotText
+		}
+		return out, nil
+	}
+}
+
+var errNotText = errors.New("decoded data is not text")
+
+// AutoDecode 根据magic与启发式规则逐层解码(gzip, zstd, zlib, bzip2, xz, deflate, UTF-16, hex, base64), 直到内容不再变化
+// 返回最终结果与依次执行的DSL操作符, strings.Join(chain, "|") 即可作为DSL重放
+func AutoDecode(data []byte) ([]byte, []string) {
+	layers := PeelLayers(data, AutoDecodeMaxDepth)
+	if len(layers) == 0 {
+		return data, nil
+	}
+	chain := make([]string, len(layers))
+	for i, layer := range layers {
+		chain[i] = layer.Op
+	}
+	return layers[len(layers)-1].Data, chain
+}
+
+// Layer 逐层解码中的一层, Data为执行Op之后的结果
+type Layer struct {
+	Op   string
+	Data []byte
+}
+
+// PeelLayers 与 AutoDecode 相同, 但返回每一层的中间结果, 用于展示多重编码payload的解码过程
+// 不包含原始输入, 无法解码时返回空; maxDepth<=0时使用 AutoDecodeMaxDepth, 出现重复的中间结果时停止
+func PeelLayers(data []byte, maxDepth int) []Layer {
+	if maxDepth <= 0 {
+		maxDepth = AutoDecodeMaxDepth
+	}
+	// 只保存每层的摘要, 避免在Layer.Data之外再持有一份完整的副本
+	seen := map[[sha256.Size]byte]bool{sha256.Sum256(data): true}
+	var layers []Layer
+	for len(layers) < maxDepth {
+		out, name, ok := autoDecodeOnce(data)
+		if !ok {
+			break
+		}
+		sum := sha256.Sum256(out)
+		if seen[sum] {
+			break
+		}
+		seen[sum] = true
+		layers = append(layers, Layer{Op: name, Data: out})
+		data = out
+	}
+	return layers
+}
+
+func autoDecodeOnce(data []byte) ([]byte, string, bool) {
+	for _, d := range autoDecoders {
+		if !d.match(data) {
+			continue
+		}
+		out, err := d.decode(data)
+		if err != nil || len(out) == 0 || bytes.Equal(out, data) {
+			continue
+		}
+		return out, d.name, true
+	}
+	return nil, "", false
+}
+
+func hasKnownMagic(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0x1f, 0x8b}) ||
+		bytes.HasPrefix(data, []byte{0x28, 0xb5, 0x2f, 0xfd}) ||
+		isZlibHeader(data) ||
+		isBzip2Header(data) ||
+		bytes.HasPrefix(data, xzMagic)
+}
+
+// looksLikeText 是否为合法UTF-8且至少90%为可打印字符或空白
+func looksLikeText(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	var total, printable int
+	for _, r := range string(data) {
+		total++
+		if unicode.IsPrint(r) || unicode.IsSpace(r) {
+			printable++
+		}
+	}
+	return printable*10 >= total*9
+}
+
+func isCharset(data []byte, charset string) bool {
+	for _, c := range data {
+		if strings.IndexByte(charset, c) < 0 {
+			return false
+		}
+	}
+	return true
+}
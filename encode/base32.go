@@ -0,0 +1,77 @@
+package encode
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+)
+
+func Base32Encode(b []byte) string {
+	return base32.StdEncoding.EncodeToString(b)
+}
+
+// Base32HexEncode 使用扩展hex字母表(0-9A-V)编码
+func Base32HexEncode(b []byte) string {
+	return base32.HexEncoding.EncodeToString(b)
+}
+
+func Base32Decode(s string) []byte {
+	data, err := Base32DecodeE(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// Base32DecodeE 解码标准字母表的base32, 忽略大小写与空白, 兼容省略padding的输入(如TOTP secret)
+func Base32DecodeE(s string) ([]byte, error) {
+	return base32.StdEncoding.DecodeString(normalizeBase32(s))
+}
+
+// Base32HexDecodeE 解码扩展hex字母表的base32, 忽略大小写与空白, 兼容省略padding的输入
+func Base32HexDecodeE(s string) ([]byte, error) {
+	return base32.HexEncoding.DecodeString(normalizeBase32(s))
+}
+
+func normalizeBase32(s string) string {
+	s = strings.ToUpper(strings.Join(strings.Fields(s), ""))
+	s = strings.TrimRight(s, "=")
+	if n := len(s) % 8; n != 0 {
+		s += strings.Repeat("=", 8-n)
+	}
+	return s
+}
+
+func init() {
+	// b32en:hex:nopad, 参数可选且顺序任意
+	RegisterDSL("b32en", func(data []byte, args ...string) ([]byte, error) {
+		enc := base32.StdEncoding
+		var nopad bool
+		for _, arg := range args {
+			switch arg {
+			case "hex":
+				enc = base32.HexEncoding
+			case "nopad":
+				nopad = true
+			default:
+				return nil, fmt.Errorf("b32en: unknown option %q", arg)
+			}
+		}
+		if nopad {
+			enc = enc.WithPadding(base32.NoPadding)
+		}
+		return []byte(enc.EncodeToString(data)), nil
+	})
+	// b32de:hex
+	RegisterDSL("b32de", func(data []byte, args ...string) ([]byte, error) {
+		decode := Base32DecodeE
+		if len(args) > 0 && args[0] == "hex" {
+			decode = Base32HexDecodeE
+		}
+		bs, err := decode(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("b32de: %v", err)
+		}
+		return bs, nil
+	})
+}
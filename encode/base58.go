@@ -0,0 +1,109 @@
+package encode
+
+import (
+	"fmt"
+)
+
+// Base58Alphabet 比特币使用的base58字母表, 去掉了易混淆的 0 O I l
+const Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func Base58Encode(b []byte) string {
+	return string(bigRadixEncode(b, Base58Alphabet))
+}
+
+func Base58Decode(s string) []byte {
+	data, err := Base58DecodeE(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func Base58DecodeE(s string) ([]byte, error) {
+	return bigRadixDecode(s, Base58Alphabet)
+}
+
+// bigRadixEncode 将输入视为一个大端大整数转换为alphabet进制, 前导的0字节编码为alphabet[0]
+func bigRadixEncode(b []byte, alphabet string) []byte {
+	base := len(alphabet)
+	var zeros int
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	// 每个字节最多需要 log(256)/log(base) 位, base>=2 时不超过8
+	digits := make([]byte, 0, len(b)*8)
+	for _, c := range b[zeros:] {
+		carry := int(c)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % base)
+			carry /= base
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%base))
+			carry /= base
+		}
+	}
+
+	out := make([]byte, zeros+len(digits))
+	for i := 0; i < zeros; i++ {
+		out[i] = alphabet[0]
+	}
+	for i, d := range digits {
+		out[len(out)-1-i] = alphabet[d]
+	}
+	return out
+}
+
+func bigRadixDecode(s string, alphabet string) ([]byte, error) {
+	var table [256]int
+	for i := range table {
+		table[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		table[alphabet[i]] = i
+	}
+	base := len(alphabet)
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	bs := make([]byte, 0, len(s))
+	for i := zeros; i < len(s); i++ {
+		carry := table[s[i]]
+		if carry < 0 {
+			return nil, fmt.Errorf("illegal character %q at offset %d", s[i], i)
+		}
+		for j := range bs {
+			carry += int(bs[j]) * base
+			bs[j] = byte(carry & 0xff)
+			carry >>= 8
+		}
+		for carry > 0 {
+			bs = append(bs, byte(carry&0xff))
+			carry >>= 8
+		}
+	}
+
+	out := make([]byte, zeros+len(bs))
+	for i, b := range bs {
+		out[len(out)-1-i] = b
+	}
+	return out, nil
+}
+
+func init() {
+	RegisterDSL("b58en", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(Base58Encode(data)), nil
+	})
+	RegisterDSL("b58de", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := Base58DecodeE(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("b58de: %v", err)
+		}
+		return bs, nil
+	})
+}
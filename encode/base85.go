@@ -0,0 +1,118 @@
+package encode
+
+import (
+	"encoding/ascii85"
+	"fmt"
+	"strings"
+)
+
+// Base85Alphabet git与python base64.b85encode使用的字母表(RFC 1924)
+const Base85Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// Ascii85Encode Adobe ascii85编码, 不带 <~ ~> 定界符
+func Ascii85Encode(b []byte) string {
+	dst := make([]byte, ascii85.MaxEncodedLen(len(b)))
+	n := ascii85.Encode(dst, b)
+	return string(dst[:n])
+}
+
+// Ascii85DecodeE 解码ascii85, 兼容PDF流中的 <~ ~> 定界符与空白
+func Ascii85DecodeE(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "<~")
+	s = strings.TrimSuffix(s, "~>")
+	dst := make([]byte, 4*len(s))
+	n, _, err := ascii85.Decode(dst, []byte(s), true)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:n], nil
+}
+
+// Base85Encode 使用 Base85Alphabet 编码, 结尾不足4字节的分组输出n+1个字符, 与python b85encode(pad=False)一致
+func Base85Encode(b []byte) string {
+	out := make([]byte, 0, (len(b)+3)/4*5)
+	for len(b) > 0 {
+		var chunk [4]byte
+		n := copy(chunk[:], b)
+		b = b[n:]
+
+		v := uint32(chunk[0])<<24 | uint32(chunk[1])<<16 | uint32(chunk[2])<<8 | uint32(chunk[3])
+		var enc [5]byte
+		for i := 4; i >= 0; i-- {
+			enc[i] = Base85Alphabet[v%85]
+			v /= 85
+		}
+		out = append(out, enc[:n+1]...)
+	}
+	return string(out)
+}
+
+func Base85Decode(s string) []byte {
+	data, err := Base85DecodeE(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func Base85DecodeE(s string) ([]byte, error) {
+	var table [256]int
+	for i := range table {
+		table[i] = -1
+	}
+	for i := 0; i < len(Base85Alphabet); i++ {
+		table[Base85Alphabet[i]] = i
+	}
+
+	s = strings.Join(strings.Fields(s), "")
+	if len(s)%5 == 1 {
+		return nil, fmt.Errorf("invalid base85 length %d", len(s))
+	}
+	out := make([]byte, 0, len(s)/5*4+4)
+	for i := 0; i < len(s); i += 5 {
+		group := s[i:]
+		if len(group) > 5 {
+			group = group[:5]
+		}
+		var v uint64
+		for j := 0; j < 5; j++ {
+			d := 84 // 不足5个字符的分组以最大值补齐
+			if j < len(group) {
+				if d = table[group[j]]; d < 0 {
+					return nil, fmt.Errorf("illegal base85 character %q at offset %d", group[j], i+j)
+				}
+			}
+			v = v*85 + uint64(d)
+		}
+		if v > 0xffffffff {
+			return nil, fmt.Errorf("base85 overflow at offset %d", i)
+		}
+		chunk := []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+		out = append(out, chunk[:len(group)-1]...)
+	}
+	return out, nil
+}
+
+func init() {
+	RegisterDSL("a85en", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(Ascii85Encode(data)), nil
+	})
+	RegisterDSL("a85de", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := Ascii85DecodeE(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("a85de: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("b85en", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(Base85Encode(data)), nil
+	})
+	RegisterDSL("b85de", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := Base85DecodeE(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("b85de: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -0,0 +1,176 @@
+package encode
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	Base36Alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	Base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// BaseN 使用自定义字母表的编解码器, 常用于还原恶意样本与配置中打乱字母表的base64或base36/base62
+// 64与32位字母表按base64/base32的位分组编码, 即与标准库的 NewEncoding 一致, 其余长度按大数进制转换(与base58相同)
+type BaseN struct {
+	alphabet string
+	b64      *base64.Encoding
+	b32      *base32.Encoding
+}
+
+// NewBaseN 创建自定义字母表的编解码器, 字母表长度需在2~256之间且不能有重复字符, 不能包含 \r \n
+func NewBaseN(alphabet string) (*BaseN, error) {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return nil, fmt.Errorf("invalid alphabet length %d", len(alphabet))
+	}
+	// 换行总是作为分隔被忽略, 标准库的 NewEncoding 也会因此panic
+	if strings.ContainsAny(alphabet, "\r\n") {
+		return nil, fmt.Errorf("alphabet contains newline")
+	}
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		if seen[alphabet[i]] {
+			return nil, fmt.Errorf("duplicate character %q in alphabet", alphabet[i])
+		}
+		seen[alphabet[i]] = true
+	}
+
+	codec := &BaseN{alphabet: alphabet}
+	switch len(alphabet) {
+	case 64:
+		codec.b64 = base64.NewEncoding(alphabet)
+		if seen['='] {
+			codec.b64 = codec.b64.WithPadding(base64.NoPadding)
+		}
+	case 32:
+		codec.b32 = base32.NewEncoding(alphabet)
+		if seen['='] {
+			codec.b32 = codec.b32.WithPadding(base32.NoPadding)
+		}
+	}
+	return codec, nil
+}
+
+// Alphabet 返回编解码器使用的字母表
+func (codec *BaseN) Alphabet() string {
+	return codec.alphabet
+}
+
+func (codec *BaseN) Encode(b []byte) string {
+	switch {
+	case codec.b64 != nil:
+		return codec.b64.EncodeToString(b)
+	case codec.b32 != nil:
+		return codec.b32.EncodeToString(b)
+	}
+	return string(bigRadixEncode(b, codec.alphabet))
+}
+
+// Decode 解码, 会忽略不在字母表中的空白, 64/32位字母表兼容省略padding的输入
+func (codec *BaseN) Decode(s string) ([]byte, error) {
+	s = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" \t\r\n", r) && !strings.ContainsRune(codec.alphabet, r) {
+			return -1
+		}
+		return r
+	}, s)
+	switch {
+	case codec.b64 != nil:
+		if strings.IndexByte(codec.alphabet, '=') == -1 {
+			s = strings.TrimRight(s, "=")
+		}
+		return codec.b64.WithPadding(base64.NoPadding).DecodeString(s)
+	case codec.b32 != nil:
+		if strings.IndexByte(codec.alphabet, '=') == -1 {
+			s = strings.TrimRight(s, "=")
+		}
+		return codec.b32.WithPadding(base32.NoPadding).DecodeString(s)
+	}
+	return bigRadixDecode(s, codec.alphabet)
+}
+
+// BaseNEncode 使用自定义字母表编码
+func BaseNEncode(b []byte, alphabet string) (string, error) {
+	codec, err := getBaseN(alphabet)
+	if err != nil {
+		return "", err
+	}
+	return codec.Encode(b), nil
+}
+
+// BaseNDecode 使用自定义字母表解码
+func BaseNDecode(s string, alphabet string) ([]byte, error) {
+	codec, err := getBaseN(alphabet)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Decode(s)
+}
+
+var (
+	baseNCache   = make(map[string]*BaseN)
+	baseNCacheMu sync.Mutex
+)
+
+func getBaseN(alphabet string) (*BaseN, error) {
+	baseNCacheMu.Lock()
+	defer baseNCacheMu.Unlock()
+	if codec, ok := baseNCache[alphabet]; ok {
+		return codec, nil
+	}
+	codec, err := NewBaseN(alphabet)
+	if err != nil {
+		return nil, err
+	}
+	baseNCache[alphabet] = codec
+	return codec, nil
+}
+
+// baseNAlphabets DSL中可直接使用的字母表简写
+var baseNAlphabets = map[string]string{
+	"36": Base36Alphabet,
+	"58": Base58Alphabet,
+	"62": Base62Alphabet,
+	"85": Base85Alphabet,
+}
+
+func dslAlphabet(args []string) (string, error) {
+	if len(args) == 0 || args[0] == "" {
+		return "", errors.New("missing alphabet")
+	}
+	if alphabet, ok := baseNAlphabets[args[0]]; ok {
+		return alphabet, nil
+	}
+	// 字母表中未转义的 : , 会被当作参数分隔符, 这里按 : 拼回
+	return strings.Join(args, ":"), nil
+}
+
+func init() {
+	// basen:alphabet, alphabet可以是 36 58 62 85 简写, 字母表中的 | : , 需要使用 \ 转义
+	RegisterDSL("basen", func(data []byte, args ...string) ([]byte, error) {
+		alphabet, err := dslAlphabet(args)
+		if err != nil {
+			return nil, fmt.Errorf("basen: %v", err)
+		}
+		s, err := BaseNEncode(data, alphabet)
+		if err != nil {
+			return nil, fmt.Errorf("basen: %v", err)
+		}
+		return []byte(s), nil
+	})
+	RegisterDSL("unbasen", func(data []byte, args ...string) ([]byte, error) {
+		alphabet, err := dslAlphabet(args)
+		if err != nil {
+			return nil, fmt.Errorf("unbasen: %v", err)
+		}
+		bs, err := BaseNDecode(string(data), alphabet)
+		if err != nil {
+			return nil, fmt.Errorf("unbasen: %v", err)
+		}
+		return bs, nil
+	})
+}
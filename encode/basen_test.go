@@ -0,0 +1,41 @@
+package encode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBaseNAlphabet(t *testing.T) {
+	for _, alphabet := range []string{
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+\n",
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ23456\r",
+		"01\n",
+	} {
+		if _, err := NewBaseN(alphabet); err == nil {
+			t.Errorf("NewBaseN(%q) expect error", alphabet)
+		}
+	}
+
+	// 字母表中的空白不能在解码时被忽略
+	for _, alphabet := range []string{
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789 /",
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ2345\t ",
+		" 123456789",
+	} {
+		codec, err := NewBaseN(alphabet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := []byte("\xff\xfe\xfd admin")
+		got, err := codec.Decode(codec.Encode(data))
+		if err != nil || !bytes.Equal(got, data) {
+			t.Errorf("%q: got %q, %v", alphabet, got, err)
+		}
+	}
+
+	codec, _ := NewBaseN(Base36Alphabet)
+	got, err := codec.Decode(" 5c\r\n5v2\telq ")
+	if err != nil || string(got) != "admin" {
+		t.Errorf("decode with whitespace: %q, %v", got, err)
+	}
+}
@@ -0,0 +1,37 @@
+package encode
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// BrotliDecompress 解压brotli数据, 用于处理 Content-Encoding: br 的HTTP响应
+func BrotliDecompress(input []byte) ([]byte, error) {
+	return readAllPooled(brotli.NewReader(bytes.NewReader(input)))
+}
+
+func MustBrotliDecompress(input []byte) []byte {
+	output, err := BrotliDecompress(input)
+	if err != nil {
+		panic(err)
+	}
+	return output
+}
+
+// NewBrotliReader 返回brotli解压的reader
+func NewBrotliReader(r io.Reader) io.Reader {
+	return brotli.NewReader(r)
+}
+
+func init() {
+	RegisterDSL("br", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := BrotliDecompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("br: %v", err)
+		}
+		return bs, nil
+	})
+}
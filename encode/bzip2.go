@@ -0,0 +1,39 @@
+package encode
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"fmt"
+	"io"
+)
+
+// Bzip2Decompress 解压bzip2数据, 标准库只支持解压
+func Bzip2Decompress(input []byte) ([]byte, error) {
+	return readAllPooled(bzip2.NewReader(bytes.NewReader(input)))
+}
+
+// Bzip2DecompressLimited 解压bzip2数据, 输出超过maxOut字节时返回 ErrDecompressLimit
+func Bzip2DecompressLimited(input []byte, maxOut int64) ([]byte, error) {
+	return readLimited(bzip2.NewReader(bytes.NewReader(input)), maxOut)
+}
+
+// NewBzip2Reader 返回bzip2解压的reader
+func NewBzip2Reader(r io.Reader) io.Reader {
+	return bzip2.NewReader(r)
+}
+
+// isBzip2Header 判断是否为bzip2头: BZh + 块大小(1-9) + 块magic(pi的BCD)
+func isBzip2Header(data []byte) bool {
+	return len(data) >= 10 && bytes.HasPrefix(data, []byte("BZh")) && data[3] >= '1' && data[3] <= '9' &&
+		(bytes.Equal(data[4:10], []byte{0x31, 0x41, 0x59, 0x26, 0x53, 0x59}) || bytes.Equal(data[4:10], []byte{0x17, 0x72, 0x45, 0x38, 0x50, 0x90}))
+}
+
+func init() {
+	RegisterDSL("bunzip2", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := Bzip2Decompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("bunzip2: %v", err)
+		}
+		return bs, nil
+	})
+}
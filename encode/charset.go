@@ -0,0 +1,65 @@
+package encode
+
+import (
+	"fmt"
+
+	"github.com/chainreactors/utils/iutils"
+)
+
+func init() {
+	RegisterDSL("gbk2utf8", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := iutils.GBKToUTF8(data)
+		if err != nil {
+			return nil, fmt.Errorf("gbk2utf8: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("utf82gbk", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := iutils.UTF8ToGBK(data)
+		if err != nil {
+			return nil, fmt.Errorf("utf82gbk: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("big52utf8", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := iutils.Big5ToUTF8(data)
+		if err != nil {
+			return nil, fmt.Errorf("big52utf8: %v", err)
+		}
+		return bs, nil
+	})
+	// latin1 将latin1(iso-8859-1)解码为UTF-8
+	RegisterDSL("latin1", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := iutils.DecodeCharset(data, "latin1")
+		if err != nil {
+			return nil, fmt.Errorf("latin1: %v", err)
+		}
+		return bs, nil
+	})
+	// toutf8 自动识别gbk/big5并转为UTF-8, 无法识别时原样返回
+	RegisterDSL("toutf8", func(data []byte, args ...string) ([]byte, error) {
+		return iutils.ToUTF8(data), nil
+	})
+	// charset:name 将指定编码解码为UTF-8, 支持的编码见 iutils.GetCharset
+	RegisterDSL("charset", func(data []byte, args ...string) ([]byte, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("charset: missing charset name")
+		}
+		bs, err := iutils.DecodeCharset(data, args[0])
+		if err != nil {
+			return nil, fmt.Errorf("charset: %v", err)
+		}
+		return bs, nil
+	})
+	// tocharset:name 将UTF-8编码为指定编码
+	RegisterDSL("tocharset", func(data []byte, args ...string) ([]byte, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("tocharset: missing charset name")
+		}
+		bs, err := iutils.EncodeCharset(data, args[0])
+		if err != nil {
+			return nil, fmt.Errorf("tocharset: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -1,36 +1,275 @@
 package encode
 
-import "strings"
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
 
 func DSLParserToString(s string) (string, bool) {
 	bs, ok := DSLParser(s)
 	return string(bs), ok
 }
 
-func DSLParser(s string) ([]byte, bool) {
-	var bs []byte
-	var operator, content string
+// ErrUnknownDSL 输入不是以已注册的操作符开头
+var ErrUnknownDSL = errors.New("unknown dsl operator")
 
-	if i := strings.Index(s, "|"); i > 0 {
-		operator = s[:i]
-		content = s[i+1:]
-	} else {
-		return []byte(s), false
-	}
-
-	switch operator {
-	case "b64de":
-		bs = Base64Decode(content)
-	case "b64en":
-		bs = []byte(Base64Encode([]byte(content)))
-	case "unhex":
-		bs = HexDecode(content)
-	case "hex":
-		bs = []byte(HexEncode([]byte(content)))
-	case "md5":
-		bs = []byte(Md5Hash([]byte(content)))
-	default:
+// DSLParser 解析 "op1|op2|...|content" 形式的DSL, 从左到右依次对content执行各个操作符
+// 例如 "b64de|unhex|md5|..." 先base64解码, 再hex解码, 最后计算md5
+//
+// 操作符可以携带参数, 形如 "name:arg1:arg2" 或 "name:arg1,arg2", 例如 "xor:0x6b", "cut:0:16"
+// 参数中的 | : , 需要使用 \ 转义
+//
+// 任意阶段出错时返回原始content与false, 需要错误信息时使用 DSLParserE
+func DSLParser(s string) ([]byte, bool) {
+	stages, content := splitDSL(s)
+	if stages == nil {
+		return []byte(content), false
+	}
+	bs, err := runDSL(stages, content)
+	if err != nil {
 		return []byte(content), false
 	}
 	return bs, true
 }
+
+// DSLParserE 与 DSLParser 相同, 但返回具体的错误, 不是DSL时返回 ErrUnknownDSL
+func DSLParserE(s string) ([]byte, error) {
+	stages, content := splitDSL(s)
+	if stages == nil {
+		return []byte(content), ErrUnknownDSL
+	}
+	return runDSL(stages, content)
+}
+
+// splitDSL 拆分出操作符与content, 第一个操作符未注册时stages为nil
+func splitDSL(s string) ([]dslStage, string) {
+	token, content, ok := nextDSLToken(s)
+	if !ok || token == "" {
+		return nil, s
+	}
+	stage := parseDSLStage(token)
+	if !isDSLOperator(stage.name) {
+		return nil, content
+	}
+
+	stages := []dslStage{stage}
+	for {
+		token, rest, ok := nextDSLToken(content)
+		if !ok || token == "" {
+			break
+		}
+		stage := parseDSLStage(token)
+		if !isDSLOperator(stage.name) {
+			break
+		}
+		stages = append(stages, stage)
+		content = rest
+	}
+	return stages, content
+}
+
+func runDSL(stages []dslStage, content string) ([]byte, error) {
+	bs := []byte(content)
+	for _, stage := range stages {
+		fn, _ := GetDSL(stage.name)
+		out, err := fn(bs, stage.args...)
+		if err != nil {
+			return nil, err
+		}
+		bs = out
+	}
+	return bs, nil
+}
+
+type dslStage struct {
+	name string
+	args []string
+}
+
+// nextDSLToken 在第一个未转义的 | 处切分, 返回操作符与剩余部分
+func nextDSLToken(s string) (token, rest string, ok bool) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '|':
+			return s[:i], s[i+1:], true
+		}
+	}
+	return "", s, false
+}
+
+// parseDSLStage 解析 "name:arg1:arg2" 或 "name:arg1,arg2", 参数以未转义的 : 或 , 分隔
+func parseDSLStage(token string) dslStage {
+	var stage dslStage
+	var buf []byte
+	named := false
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		switch {
+		case c == '\\' && i+1 < len(token):
+			i++
+			buf = append(buf, token[i])
+		case c == ':' && !named:
+			stage.name = string(buf)
+			named = true
+			buf = buf[:0]
+		case (c == ':' || c == ',') && named:
+			stage.args = append(stage.args, string(buf))
+			buf = buf[:0]
+		default:
+			buf = append(buf, c)
+		}
+	}
+	if named {
+		stage.args = append(stage.args, string(buf))
+	} else {
+		stage.name = string(buf)
+	}
+	return stage
+}
+
+// DSLFunc DSL操作符的实现, data为上一阶段的输出, args为操作符参数
+type DSLFunc func(data []byte, args ...string) ([]byte, error)
+
+var (
+	dslOperators   = make(map[string]DSLFunc)
+	dslOperatorsMu sync.RWMutex
+)
+
+func init() {
+	RegisterDSL("b64de", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := Base64DecodeE(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("b64de: %v", err)
+		}
+		return bs, nil
+	})
+	// b64en:url:raw, url使用URL-safe字母表, raw不带padding
+	RegisterDSL("b64en", func(data []byte, args ...string) ([]byte, error) {
+		enc := base64.StdEncoding
+		var raw bool
+		for _, arg := range args {
+			switch arg {
+			case "url":
+				enc = base64.URLEncoding
+			case "raw":
+				raw = true
+			default:
+				return nil, fmt.Errorf("b64en: unknown option %q", arg)
+			}
+		}
+		if raw {
+			enc = enc.WithPadding(base64.NoPadding)
+		}
+		return []byte(enc.EncodeToString(data)), nil
+	})
+	RegisterDSL("unhex", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := HexDecodeE(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("unhex: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("hex", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(HexEncode(data)), nil
+	})
+	// gzip:level, level可省略
+	RegisterDSL("gzip", func(data []byte, args ...string) ([]byte, error) {
+		level := gzip.DefaultCompression
+		if len(args) > 0 && args[0] != "" {
+			var err error
+			if level, err = strconv.Atoi(args[0]); err != nil {
+				return nil, fmt.Errorf("gzip: invalid level %q", args[0])
+			}
+		}
+		bs, err := GzipCompressLevel(data, level)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("gunzip", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := GzipDecompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("gunzip: %v", err)
+		}
+		return bs, nil
+	})
+	// cut:start:end, 与python切片一致, 支持负数下标, end可省略
+	RegisterDSL("cut", func(data []byte, args ...string) ([]byte, error) {
+		return cutBytes(data, args)
+	})
+}
+
+// RegisterDSL 注册自定义DSL操作符, 同名操作符会被覆盖
+// 操作符同时会注册为模板函数, 例如 {{name(arg1, content)}}, 已存在同名模板函数时跳过
+func RegisterDSL(name string, fn DSLFunc) {
+	dslOperatorsMu.Lock()
+	dslOperators[name] = fn
+	dslOperatorsMu.Unlock()
+	registerDSLTemplate(name)
+}
+
+// GetDSL 获取已注册的DSL操作符
+func GetDSL(name string) (DSLFunc, bool) {
+	dslOperatorsMu.RLock()
+	defer dslOperatorsMu.RUnlock()
+	fn, ok := dslOperators[name]
+	return fn, ok
+}
+
+// DSLOperators 返回已注册的全部操作符名, 按字母排序
+func DSLOperators() []string {
+	dslOperatorsMu.RLock()
+	names := make([]string, 0, len(dslOperators))
+	for name := range dslOperators {
+		names = append(names, name)
+	}
+	dslOperatorsMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+func isDSLOperator(operator string) bool {
+	_, ok := GetDSL(operator)
+	return ok
+}
+
+func cutBytes(bs []byte, args []string) ([]byte, error) {
+	start, end := 0, len(bs)
+	var err error
+	if len(args) > 0 && args[0] != "" {
+		if start, err = strconv.Atoi(args[0]); err != nil {
+			return nil, fmt.Errorf("cut: invalid start %q", args[0])
+		}
+	}
+	if len(args) > 1 && args[1] != "" {
+		if end, err = strconv.Atoi(args[1]); err != nil {
+			return nil, fmt.Errorf("cut: invalid end %q", args[1])
+		}
+	}
+	start, end = sliceIndex(start, len(bs)), sliceIndex(end, len(bs))
+	if start >= end {
+		return []byte{}, nil
+	}
+	return bs[start:end], nil
+}
+
+func sliceIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
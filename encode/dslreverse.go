@@ -0,0 +1,196 @@
+package encode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// dslInverseFunc 根据操作符参数生成逆操作链, 例如 urldecode:2 的逆操作为两次 urlencode
+type dslInverseFunc func(args []string) ([]string, error)
+
+var (
+	dslInverses   = make(map[string]dslInverseFunc)
+	dslInversesMu sync.RWMutex
+)
+
+// RegisterDSLInverse 注册操作符name的逆操作, keepArgs为true时逆操作沿用原参数(如xor, 加解密的key),
+// 否则丢弃参数(如 gzip:9 的逆操作为 gunzip)
+func RegisterDSLInverse(name, inverse string, keepArgs bool) {
+	registerDSLInverseFunc(name, func(args []string) ([]string, error) {
+		if keepArgs {
+			return []string{formatDSLStage(inverse, args)}, nil
+		}
+		return []string{inverse}, nil
+	})
+}
+
+func registerDSLInverseFunc(name string, fn dslInverseFunc) {
+	dslInversesMu.Lock()
+	dslInverses[name] = fn
+	dslInversesMu.Unlock()
+}
+
+// registerDSLPair 注册一对互逆的操作符
+func registerDSLPair(a, b string, keepArgs bool) {
+	RegisterDSLInverse(a, b, keepArgs)
+	RegisterDSLInverse(b, a, keepArgs)
+}
+
+// ReverseChain 生成操作链的逆操作链, 例如 AutoDecode 返回的 ["b64de", "unhex"] 得到 ["hex", "b64en"]
+// 即按相反顺序执行每个操作的逆操作, 可以将解码结果重新编码为原始payload; 存在不可逆的操作(如md5)时返回错误
+func ReverseChain(chain []string) ([]string, error) {
+	reversed := make([]string, 0, len(chain))
+	for i := len(chain) - 1; i >= 0; i-- {
+		stage := parseDSLStage(chain[i])
+		dslInversesMu.RLock()
+		fn, ok := dslInverses[stage.name]
+		dslInversesMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("dsl operator %q is not reversible", stage.name)
+		}
+		stages, err := fn(stage.args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", stage.name, err)
+		}
+		reversed = append(reversed, stages...)
+	}
+	return reversed, nil
+}
+
+// ReverseDSL 与 ReverseChain 相同, 但输入输出为 "op1|op2" 形式的DSL, 例如 "b64de|unhex" 得到 "hex|b64en"
+func ReverseDSL(spec string) (string, error) {
+	var chain []string
+	for spec != "" {
+		token, rest, ok := nextDSLToken(spec)
+		if !ok {
+			token, rest = spec, ""
+		}
+		if token != "" {
+			chain = append(chain, token)
+		}
+		spec = rest
+	}
+	reversed, err := ReverseChain(chain)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(reversed, "|"), nil
+}
+
+var dslArgEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, `:`, `\:`, `,`, `\,`)
+
+func formatDSLStage(name string, args []string) string {
+	if len(args) == 0 {
+		return name
+	}
+	escaped := make([]string, len(args))
+	for i, arg := range args {
+		escaped[i] = dslArgEscaper.Replace(arg)
+	}
+	return name + ":" + strings.Join(escaped, ":")
+}
+
+func init() {
+	for _, pair := range [][2]string{
+		{"b64de", "b64en"},
+		{"unhex", "hex"},
+		{"gunzip", "gzip"},
+		{"unzstd", "zstd"},
+		{"unzlib", "zlib"},
+		{"unsnappy", "snappy"},
+		{"unlz4", "lz4"},
+		{"b58de", "b58en"},
+		{"a85de", "a85en"},
+		{"b85de", "b85en"},
+		{"htmlde", "htmlen"},
+		{"ude", "uen"},
+		{"utf16de", "utf16en"},
+		{"unhexdump", "hexdump"},
+		{"punyde", "punyen"},
+		{"qpde", "qpen"},
+		{"gbk2utf8", "utf82gbk"},
+	} {
+		registerDSLPair(pair[0], pair[1], false)
+	}
+	RegisterDSLInverse("gunzip-partial", "gzip", false)
+	RegisterDSLInverse("unzlib-partial", "zlib", false)
+	RegisterDSLInverse("inflate-partial", "deflate", false)
+	RegisterDSLInverse("big52utf8", "tocharset:big5", false)
+	RegisterDSLInverse("latin1", "tocharset:latin1", false)
+
+	registerDSLPair("inflate", "deflate", true)
+	registerDSLPair("unbasen", "basen", true)
+	registerDSLPair("charset", "tocharset", true)
+	RegisterDSLInverse("xor", "xor", true)
+	RegisterDSLInverse("rc4", "rc4", true)
+	RegisterDSLInverse("rot13", "rot13", false)
+
+	// b32de只接受hex参数, b32en的nopad不影响解码
+	b32 := func(inverse string) dslInverseFunc {
+		return func(args []string) ([]string, error) {
+			for _, arg := range args {
+				if arg == "hex" {
+					return []string{inverse + ":hex"}, nil
+				}
+			}
+			return []string{inverse}, nil
+		}
+	}
+	registerDSLInverseFunc("b32de", b32("b32en"))
+	registerDSLInverseFunc("b32en", b32("b32de"))
+	registerDSLInverseFunc("caesar", func(args []string) ([]string, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("missing shift")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid shift %q", args[0])
+		}
+		return []string{"caesar:" + strconv.Itoa(-n)}, nil
+	})
+	registerDSLInverseFunc("tr", func(args []string) ([]string, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("usage tr:from:to")
+		}
+		// 只有from与to是同一组字符的置换时才可逆, 否则无法区分替换产生的字符与原有的字符
+		var count [256]int
+		for i := 0; i < len(args[0]); i++ {
+			count[args[0][i]]++
+		}
+		for i := 0; i < len(args[1]); i++ {
+			count[args[1][i]]--
+		}
+		for _, c := range count {
+			if c != 0 {
+				return nil, fmt.Errorf("substitution is not a permutation")
+			}
+		}
+		return []string{formatDSLStage("tr", []string{args[1], args[0]})}, nil
+	})
+
+	// urldecode:n 解码n次, 逆操作为n次urlencode; n<=0 解码到稳定为止, 无法确定编码次数
+	RegisterDSLInverse("urlencode", "urldecode", false)
+	registerDSLInverseFunc("urldecode", func(args []string) ([]string, error) {
+		n := 1
+		for _, arg := range args {
+			if arg == "form" {
+				continue
+			}
+			i, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid option %q", arg)
+			}
+			n = i
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("decode until stable is not reversible")
+		}
+		stages := make([]string, n)
+		for i := range stages {
+			stages[i] = "urlencode"
+		}
+		return stages, nil
+	})
+}
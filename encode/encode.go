@@ -8,25 +8,71 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"github.com/chainreactors/utils/iutils"
 	"github.com/go-dedup/simhash"
 	"github.com/twmb/murmur3"
-	"io/ioutil"
+	"io"
 	"strconv"
 	"strings"
 )
 
 func Base64Decode(s string) []byte {
-	data, err := base64.StdEncoding.DecodeString(s)
+	data, err := Base64DecodeE(s)
 	if err != nil {
 		panic(err)
 	}
 	return data
 }
 
+// Base64DecodeE 与 Base64Decode 相同, 但返回错误而不是panic
+// 会根据输入自动选择字母表: 包含 - _ 时视为URL-safe, 缺少padding时视为raw, 同时忽略空白
+func Base64DecodeE(s string) ([]byte, error) {
+	if strings.ContainsAny(s, " \t\r\n") {
+		s = strings.Join(strings.Fields(s), "")
+	}
+	enc := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.URLEncoding
+	}
+	if !strings.HasSuffix(s, "=") && len(s)%4 != 0 {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	return enc.DecodeString(s)
+}
+
+// Base64URLDecode 解码URL-safe字母表的base64, 兼容有无padding, 解码失败时panic
+func Base64URLDecode(s string) []byte {
+	data, err := Base64URLDecodeE(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// Base64URLDecodeE 解码URL-safe字母表的base64, 兼容有无padding
+func Base64URLDecodeE(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
 func Base64Encode(b []byte) string {
 	return base64.StdEncoding.EncodeToString(b)
 }
 
+// Base64URLEncode 使用URL-safe字母表(- _)编码, 保留padding
+func Base64URLEncode(b []byte) string {
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+// Base64RawEncode 使用标准字母表编码, 不带padding
+func Base64RawEncode(b []byte) string {
+	return base64.RawStdEncoding.EncodeToString(b)
+}
+
+// Base64RawURLEncode 使用URL-safe字母表编码, 不带padding, 即JWT使用的格式
+func Base64RawURLEncode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
 func XorEncode(bs []byte, keys []byte, cursor int) []byte {
 	if len(keys) == 0 {
 		return bs
@@ -40,13 +86,18 @@ func XorEncode(bs []byte, keys []byte, cursor int) []byte {
 }
 
 func HexDecode(s string) []byte {
-	b, err := hex.DecodeString(s)
+	b, err := HexDecodeE(s)
 	if err != nil {
 		panic(err)
 	}
 	return b
 }
 
+// HexDecodeE 与 HexDecode 相同, 但返回错误而不是panic
+func HexDecodeE(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
+
 func HexEncode(b []byte) string {
 	return hex.EncodeToString(b)
 }
@@ -56,10 +107,19 @@ func Md5Hash(raw []byte) string {
 	return hex.EncodeToString(m[:])
 }
 
+// Mmh3Hash32 计算favicon hash并以十进制字符串返回, 等价于 FaviconHash
 func Mmh3Hash32(raw []byte) string {
-	var h32 = murmur3.New32()
-	_, _ = h32.Write(standBase64(raw))
-	return fmt.Sprintf("%d", int32(h32.Sum32()))
+	return strconv.Itoa(int(FaviconHash(raw)))
+}
+
+// Murmur3Hash32 计算32位murmur3, 以有符号整数返回, 与python mmh3.hash(data, seed)一致
+func Murmur3Hash32(raw []byte, seed uint32) int32 {
+	return int32(murmur3.SeedSum32(seed, raw))
+}
+
+// FaviconHash shodan风格的favicon hash, 即 mmh3(base64_with_newlines(body)), 对应 http.favicon.hash 语法
+func FaviconHash(raw []byte) int32 {
+	return Murmur3Hash32(standBase64(raw), 0)
 }
 
 func standBase64(braw []byte) []byte {
@@ -113,6 +173,47 @@ func DeflateCompress(input []byte) ([]byte, error) {
 	return bf.Bytes(), nil
 }
 
+// DeflateCompressClosed 压缩并关闭deflate流, 输出是完整的deflate数据, 解压时不会出现unexpected EOF
+// DeflateCompress 只Flush不写入结束块, 为兼容旧数据保留原行为
+func DeflateCompressClosed(input []byte) ([]byte, error) {
+	var bf bytes.Buffer
+	flater, err := flate.NewWriter(&bf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := flater.Write(input); err != nil {
+		return nil, err
+	}
+	if err := flater.Close(); err != nil {
+		return nil, err
+	}
+	return bf.Bytes(), nil
+}
+
+// DeflateCompressDict 使用预置字典压缩, 对大量结构相似的短记录(如扫描结果json)能显著提高压缩率
+// 解压时必须使用相同的字典
+func DeflateCompressDict(input, dict []byte) ([]byte, error) {
+	var bf bytes.Buffer
+	flater, err := flate.NewWriterDict(&bf, flate.BestCompression, dict)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := flater.Write(input); err != nil {
+		return nil, err
+	}
+	if err := flater.Close(); err != nil {
+		return nil, err
+	}
+	return bf.Bytes(), nil
+}
+
+// DeflateDeCompressDict 使用预置字典解压 DeflateCompressDict 的输出
+func DeflateDeCompressDict(input, dict []byte) ([]byte, error) {
+	r := flate.NewReaderDict(bytes.NewReader(input), dict)
+	defer r.Close()
+	return readAllPooled(r)
+}
+
 func MustDeflateDeCompress(input []byte) []byte {
 	output, err := DeflateDeCompress(input)
 	if err != nil {
@@ -123,10 +224,12 @@ func MustDeflateDeCompress(input []byte) []byte {
 	return output
 }
 
+// DeflateDeCompress 解压deflate数据, 数据被截断或缺少结束块(如 DeflateCompress 的输出)时返回已解压的数据与 io.ErrUnexpectedEOF
+// 需要忽略截断时使用 MustDeflateDeCompress
 func DeflateDeCompress(input []byte) ([]byte, error) {
-	rdata := bytes.NewReader(input)
-	r := flate.NewReader(rdata)
-	return ioutil.ReadAll(r)
+	r := flate.NewReader(bytes.NewReader(input))
+	defer r.Close()
+	return readAllPooled(r)
 }
 
 func MustGzipCompress(input []byte) []byte {
@@ -140,9 +243,17 @@ func MustGzipCompress(input []byte) []byte {
 }
 
 func GzipCompress(data []byte) ([]byte, error) {
+	return GzipCompressLevel(data, gzip.DefaultCompression)
+}
+
+// GzipCompressLevel 以指定的压缩等级(gzip.BestSpeed ~ gzip.BestCompression)压缩数据
+func GzipCompressLevel(data []byte, level int) ([]byte, error) {
 	var buf bytes.Buffer
-	gzipWriter := gzip.NewWriter(&buf)
-	_, err := gzipWriter.Write(data)
+	gzipWriter, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	_, err = gzipWriter.Write(data)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +285,7 @@ func GzipDecompress(data []byte) ([]byte, error) {
 	}
 	defer gzipReader.Close()
 
-	result, err := ioutil.ReadAll(gzipReader)
+	result, err := readAllPooled(gzipReader)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +293,16 @@ func GzipDecompress(data []byte) ([]byte, error) {
 	return result, nil
 }
 
+// readAllPooled 与ioutil.ReadAll相同, 但使用池化的buffer读取, 只在最后分配一次结果
+func readAllPooled(r io.Reader) ([]byte, error) {
+	buf := iutils.GetBuffer()
+	defer iutils.PutBuffer(buf)
+	_, err := buf.ReadFrom(r)
+	result := make([]byte, buf.Len())
+	copy(result, buf.Bytes())
+	return result, err
+}
+
 func IsEOF(err error) bool {
 	if strings.Contains(err.Error(), "EOF") {
 		return true
@@ -0,0 +1,139 @@
+package encode
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Entropy 计算香农熵, 单位为bit/byte, 范围0~8
+// 英文文本通常在3.5~5之间, base64约6, 压缩或加密数据接近8
+func Entropy(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	var counts [256]int
+	for _, c := range data {
+		counts[c]++
+	}
+	var entropy float64
+	total := float64(len(data))
+	for _, n := range counts {
+		if n == 0 {
+			continue
+		}
+		p := float64(n) / total
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Profile 数据的字符集统计, 各比例均为占总字节数的比例
+type Profile struct {
+	Length     int     `json:"length"`
+	Entropy    float64 `json:"entropy"`
+	UTF8       bool    `json:"utf8"`       // 是否为合法UTF-8
+	Printable  float64 `json:"printable"`  // 可打印ASCII
+	Whitespace float64 `json:"whitespace"` // 空格, \t \r \n
+	Control    float64 `json:"control"`    // 其余控制字符, 包括\x00
+	HighBit    float64 `json:"high_bit"`   // >=0x80的字节
+	Hex        bool    `json:"hex"`        // 形如hex编码: 偶数长度, 同时包含数字与 a-f, 且字母大小写一致
+	Base64     bool    `json:"base64"`     // 形如base64(含URL-safe)编码: 长度为4的倍数, padding只在结尾, 且至少混合大写, 小写, 数字中的两类
+}
+
+// Type 根据统计结果粗略分类: empty, hex, base64, text, compressed(高熵二进制, 压缩或加密), binary
+// 纯数字与 "deadbeef", "administrator" 这类单词不会被视为hex或base64
+func (p *Profile) Type() string {
+	switch {
+	case p.Length == 0:
+		return "empty"
+	case p.Hex:
+		return "hex"
+	case p.Base64 && p.Length >= 8 && p.Entropy >= base64MinEntropy(p.Length):
+		return "base64"
+	case p.UTF8 && p.Control < 0.05 && (p.HighBit > 0 || p.Printable+p.Whitespace >= 0.9):
+		return "text"
+	case p.Entropy >= 7.2:
+		return "compressed"
+	}
+	return "binary"
+}
+
+// CharsetProfile 统计数据的熵与字符分布, 用于区分文本, 编码数据与压缩/加密数据
+func CharsetProfile(data []byte) *Profile {
+	p := &Profile{
+		Length:  len(data),
+		Entropy: Entropy(data),
+		UTF8:    utf8.Valid(data),
+	}
+	if len(data) == 0 {
+		return p
+	}
+	var printable, whitespace, control, high int
+	var digit, upper, lower, hexUpper, hexLower, padding int
+	hex, b64 := true, true
+	for _, c := range data {
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			whitespace++
+		case c < 0x20 || c == 0x7f:
+			control++
+		case c >= 0x80:
+			high++
+		default:
+			printable++
+		}
+		if hex && !isHex(c) {
+			hex = false
+		}
+		// padding只能出现在结尾, 最多两个
+		if b64 && (!isBase64Char(c) || padding > 0 && c != '=') {
+			b64 = false
+		}
+		switch {
+		case '0' <= c && c <= '9':
+			digit++
+		case 'A' <= c && c <= 'Z':
+			upper++
+			if c <= 'F' {
+				hexUpper++
+			}
+		case 'a' <= c && c <= 'z':
+			lower++
+			if c <= 'f' {
+				hexLower++
+			}
+		case c == '=':
+			padding++
+		}
+	}
+	hex = hex && len(data)%2 == 0 && digit > 0 && (hexUpper > 0) != (hexLower > 0)
+
+	var classes int
+	for _, n := range []int{digit, upper, lower} {
+		if n > 0 {
+			classes++
+		}
+	}
+	b64 = b64 && len(data)%4 == 0 && padding <= 2 && classes >= 2
+	total := float64(len(data))
+	p.Printable = float64(printable) / total
+	p.Whitespace = float64(whitespace) / total
+	p.Control = float64(control) / total
+	p.HighBit = float64(high) / total
+	p.Hex, p.Base64 = hex, b64
+	return p
+}
+
+// base64MinEntropy 长度为n的base64编码数据应有的最低熵, 短数据的熵受长度限制(不超过log2(n))
+// 取期望值的75%, 排除 "passwordpassword" 这类重复的单词
+func base64MinEntropy(n int) float64 {
+	if n > 64 {
+		n = 64
+	}
+	return 0.75 * math.Log2(float64(n))
+}
+
+func isBase64Char(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+		c == '+' || c == '/' || c == '-' || c == '_' || c == '='
+}
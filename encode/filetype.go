@@ -0,0 +1,143 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+)
+
+type fileSignature struct {
+	name   string
+	offset int
+	magic  []byte
+}
+
+// 按magic长度从长到短排列, 避免短magic误匹配
+var fileSignatures = []fileSignature{
+	{"png", 0, []byte("\x89PNG\r\n\x1a\n")},
+	{"ole", 0, []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}}, // doc, xls, ppt, msi
+	{"sqlite", 0, []byte("SQLite format 3\x00")},
+	{"7z", 0, []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}},
+	{"xz", 0, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+	{"rar", 0, []byte("Rar!\x1a\x07")},
+	{"gif", 0, []byte("GIF87a")},
+	{"gif", 0, []byte("GIF89a")},
+	{"tar", 257, []byte("ustar")},
+	{"pdf", 0, []byte("%PDF-")},
+	{"wasm", 0, []byte("\x00asm")},
+	{"elf", 0, []byte("\x7fELF")},
+	{"zstd", 0, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{"pcap", 0, []byte{0xd4, 0xc3, 0xb2, 0xa1}},
+	{"pcap", 0, []byte{0xa1, 0xb2, 0xc3, 0xd4}},
+	{"pcapng", 0, []byte{0x0a, 0x0d, 0x0d, 0x0a}},
+	{"macho", 0, []byte{0xfe, 0xed, 0xfa, 0xce}},
+	{"macho", 0, []byte{0xfe, 0xed, 0xfa, 0xcf}},
+	{"macho", 0, []byte{0xce, 0xfa, 0xed, 0xfe}},
+	{"macho", 0, []byte{0xcf, 0xfa, 0xed, 0xfe}},
+	{"ico", 0, []byte{0x00, 0x00, 0x01, 0x00}},
+	{"tiff", 0, []byte("II*\x00")},
+	{"tiff", 0, []byte("MM\x00*")},
+	{"psd", 0, []byte("8BPS")},
+	{"flac", 0, []byte("fLaC")},
+	{"ogg", 0, []byte("OggS")},
+	{"swf", 0, []byte("FWS")},
+	{"swf", 0, []byte("CWS")},
+	{"bzip2", 0, []byte("BZh")},
+	{"jpeg", 0, []byte{0xff, 0xd8, 0xff}},
+	{"mp3", 0, []byte("ID3")},
+	{"gzip", 0, []byte{0x1f, 0x8b}},
+	{"bmp", 0, []byte("BM")},
+}
+
+// DetectFileType 根据magic识别常见的压缩包, 图片, office文档与可执行文件类型, 无法识别时返回空字符串
+// zip会进一步区分为 docx, xlsx, pptx, jar, apk; ole2复合文档统一返回 ole; ftyp容器按brand区分为 mp4, mov, heic 等
+func DetectFileType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")), bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		return detectZipType(data)
+	case bytes.HasPrefix(data, []byte("MZ")):
+		if isPE(data) {
+			return "pe"
+		}
+		return ""
+	case bytes.HasPrefix(data, []byte{0xca, 0xfe, 0xba, 0xbe}) && len(data) >= 8:
+		// java class与mach-o fat binary共用magic, fat binary此处为架构数量, class为版本号(>=45)
+		if binary.BigEndian.Uint32(data[4:8]) < 45 {
+			return "macho"
+		}
+		return "class"
+	case len(data) >= 12 && bytes.Equal(data[:4], []byte("RIFF")):
+		switch string(data[8:12]) {
+		case "WEBP":
+			return "webp"
+		case "WAVE":
+			return "wav"
+		case "AVI ":
+			return "avi"
+		}
+	case len(data) >= 12 && bytes.Equal(data[4:8], []byte("ftyp")):
+		return detectFtypType(string(data[8:12]))
+	}
+
+	for _, sig := range fileSignatures {
+		if len(data) >= sig.offset+len(sig.magic) && bytes.Equal(data[sig.offset:sig.offset+len(sig.magic)], sig.magic) {
+			return sig.name
+		}
+	}
+	return ""
+}
+
+// detectZipType 通过zip中的文件名区分office文档, jar与apk, 只检查开头的数据, 不解压
+func detectZipType(data []byte) string {
+	head := data
+	if len(head) > 8192 {
+		head = head[:8192]
+	}
+	switch {
+	case bytes.Contains(head, []byte("word/")):
+		return "docx"
+	case bytes.Contains(head, []byte("xl/")):
+		return "xlsx"
+	case bytes.Contains(head, []byte("ppt/")):
+		return "pptx"
+	case bytes.Contains(head, []byte("AndroidManifest.xml")), bytes.Contains(head, []byte("classes.dex")):
+		return "apk"
+	case bytes.Contains(head, []byte("META-INF/")):
+		return "jar"
+	}
+	return "zip"
+}
+
+// ftypBrands ISO base media(ftyp box)的major brand, 同一容器格式可以是视频, 音频或图片
+var ftypBrands = map[string]string{
+	"isom": "mp4", "iso2": "mp4", "iso4": "mp4", "iso5": "mp4", "iso6": "mp4",
+	"mp41": "mp4", "mp42": "mp4", "avc1": "mp4", "dash": "mp4", "M4V ": "mp4", "f4v ": "mp4",
+	"M4A ": "m4a", "M4B ": "m4a",
+	"qt  ": "mov",
+	"heic": "heic", "heix": "heic", "heim": "heic", "heis": "heic", "hevc": "heic", "hevx": "heic", "mif1": "heic", "msf1": "heic",
+	"avif": "avif", "avis": "avif",
+	"crx ": "cr3",
+}
+
+// detectFtypType 按major brand区分ftyp容器, 3gp/3g2按前缀匹配, 未知的brand返回通用的 isobmff
+func detectFtypType(brand string) string {
+	if name, ok := ftypBrands[brand]; ok {
+		return name
+	}
+	switch {
+	case strings.HasPrefix(brand, "3gp"):
+		return "3gp"
+	case strings.HasPrefix(brand, "3g2"):
+		return "3g2"
+	}
+	return "isobmff"
+}
+
+// isPE 检查 e_lfanew 指向的 PE\0\0 签名
+func isPE(data []byte) bool {
+	if len(data) < 0x40 {
+		return false
+	}
+	offset := int(binary.LittleEndian.Uint32(data[0x3c:0x40]))
+	return offset >= 0 && offset+4 <= len(data) && bytes.Equal(data[offset:offset+4], []byte("PE\x00\x00"))
+}
@@ -0,0 +1,72 @@
+package encode
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func zipWith(names ...string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, _ := zw.Create(name)
+		w.Write([]byte("test"))
+	}
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestDetectFileType(t *testing.T) {
+	pe := make([]byte, 0x80)
+	copy(pe, "MZ")
+	binary.LittleEndian.PutUint32(pe[0x3c:], 0x40)
+	copy(pe[0x40:], "PE\x00\x00")
+	dos := append([]byte(nil), pe...)
+	copy(dos[0x40:], "NE\x00\x00")
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	tw.WriteHeader(&tar.Header{Name: "a.txt", Mode: 0644, Size: 4})
+	tw.Write([]byte("test"))
+	tw.Close()
+
+	ftyp := func(brand string) []byte {
+		return append([]byte("\x00\x00\x00\x18ftyp"+brand), make([]byte, 12)...)
+	}
+
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"zip", zipWith("a.txt")},
+		{"docx", zipWith("[Content_Types].xml", "word/document.xml")},
+		{"xlsx", zipWith("[Content_Types].xml", "xl/workbook.xml")},
+		{"jar", zipWith("META-INF/MANIFEST.MF", "a/b.class")},
+		{"apk", zipWith("AndroidManifest.xml", "classes.dex")},
+		{"pe", pe},
+		{"", dos},
+		{"class", []byte{0xca, 0xfe, 0xba, 0xbe, 0x00, 0x00, 0x00, 0x34}},
+		{"macho", []byte{0xca, 0xfe, 0xba, 0xbe, 0x00, 0x00, 0x00, 0x02}},
+		{"macho", []byte{0xcf, 0xfa, 0xed, 0xfe, 0x07, 0x00, 0x00, 0x01}},
+		{"webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 ")},
+		{"wav", []byte("RIFF\x00\x00\x00\x00WAVEfmt ")},
+		{"", []byte("RIFF\x00\x00\x00\x00XXXX")},
+		{"tar", tarBuf.Bytes()},
+		{"mp4", ftyp("isom")},
+		{"mov", ftyp("qt  ")},
+		{"heic", ftyp("heic")},
+		{"avif", ftyp("avif")},
+		{"3gp", ftyp("3gp5")},
+		{"isobmff", ftyp("abcd")},
+		{"gzip", []byte{0x1f, 0x8b, 0x08, 0x00}},
+		{"", []byte("admin")},
+	}
+	for i, c := range cases {
+		if got := DetectFileType(c.data); got != c.name {
+			t.Errorf("case %d: DetectFileType = %q, want %q", i, got, c.name)
+		}
+	}
+}
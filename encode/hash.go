@@ -0,0 +1,140 @@
+package encode
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"strconv"
+)
+
+// Md5Bytes 返回原始的16字节md5摘要, hex形式见 Md5Hash
+func Md5Bytes(raw []byte) []byte {
+	m := md5.Sum(raw)
+	return m[:]
+}
+
+func Sha1Hash(raw []byte) string {
+	return HexEncode(Sha1Bytes(raw))
+}
+
+func Sha1Bytes(raw []byte) []byte {
+	m := sha1.Sum(raw)
+	return m[:]
+}
+
+func Sha256Hash(raw []byte) string {
+	return HexEncode(Sha256Bytes(raw))
+}
+
+func Sha256Bytes(raw []byte) []byte {
+	m := sha256.Sum256(raw)
+	return m[:]
+}
+
+func Sha512Hash(raw []byte) string {
+	return HexEncode(Sha512Bytes(raw))
+}
+
+func Sha512Bytes(raw []byte) []byte {
+	m := sha512.Sum512(raw)
+	return m[:]
+}
+
+var (
+	crc32cTable    = crc32.MakeTable(crc32.Castagnoli)
+	crc64ISOTable  = crc64.MakeTable(crc64.ISO)
+	crc64ECMATable = crc64.MakeTable(crc64.ECMA)
+)
+
+// Crc32 IEEE多项式的crc32, 与zlib/gzip一致
+func Crc32(raw []byte) uint32 {
+	return crc32.ChecksumIEEE(raw)
+}
+
+// Crc32C Castagnoli多项式的crc32, 常见于iSCSI, ext4与部分存储格式
+func Crc32C(raw []byte) uint32 {
+	return crc32.Checksum(raw, crc32cTable)
+}
+
+// Crc64 ECMA多项式的crc64, 与xz一致
+func Crc64(raw []byte) uint64 {
+	return crc64.Checksum(raw, crc64ECMATable)
+}
+
+// Crc64ISO ISO多项式的crc64
+func Crc64ISO(raw []byte) uint64 {
+	return crc64.Checksum(raw, crc64ISOTable)
+}
+
+func init() {
+	// mmh3:seed, 输出有符号十进制
+	RegisterDSL("mmh3", func(data []byte, args ...string) ([]byte, error) {
+		var seed uint64
+		if len(args) > 0 {
+			var err error
+			if seed, err = strconv.ParseUint(args[0], 10, 32); err != nil {
+				return nil, fmt.Errorf("mmh3: invalid seed %q", args[0])
+			}
+		}
+		return []byte(strconv.Itoa(int(Murmur3Hash32(data, uint32(seed))))), nil
+	})
+	RegisterDSL("favicon", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(Mmh3Hash32(data)), nil
+	})
+
+	// crc32, crc32:castagnoli, 输出8位hex
+	RegisterDSL("crc32", func(data []byte, args ...string) ([]byte, error) {
+		sum := Crc32(data)
+		if len(args) > 0 {
+			switch args[0] {
+			case "ieee":
+			case "castagnoli", "c":
+				sum = Crc32C(data)
+			default:
+				return nil, fmt.Errorf("crc32: unknown polynomial %q", args[0])
+			}
+		}
+		return []byte(fmt.Sprintf("%08x", sum)), nil
+	})
+	// crc64, crc64:iso, 输出16位hex
+	RegisterDSL("crc64", func(data []byte, args ...string) ([]byte, error) {
+		sum := Crc64(data)
+		if len(args) > 0 {
+			switch args[0] {
+			case "ecma":
+			case "iso":
+				sum = Crc64ISO(data)
+			default:
+				return nil, fmt.Errorf("crc64: unknown polynomial %q", args[0])
+			}
+		}
+		return []byte(fmt.Sprintf("%016x", sum)), nil
+	})
+
+	// md5, sha1, sha256, sha512 默认输出hex, 带 raw 参数时输出原始字节, 如 sha256:raw|b64en|...
+	for name, fn := range map[string]func() hash.Hash{
+		"md5":    md5.New,
+		"sha1":   sha1.New,
+		"sha256": sha256.New,
+		"sha512": sha512.New,
+	} {
+		name, fn := name, fn
+		RegisterDSL(name, func(data []byte, args ...string) ([]byte, error) {
+			h := fn()
+			h.Write(data)
+			sum := h.Sum(nil)
+			if len(args) == 0 {
+				return []byte(HexEncode(sum)), nil
+			}
+			if args[0] == "raw" {
+				return sum, nil
+			}
+			return nil, fmt.Errorf("%s: unknown option %q", name, args[0])
+		})
+	}
+}
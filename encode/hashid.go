@@ -0,0 +1,110 @@
+package encode
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+)
+
+type hashPattern struct {
+	re    *regexp.Regexp
+	names []string
+}
+
+// 带前缀或固定格式的hash, 依次匹配, 命中后不再按长度猜测
+var hashPatterns = []hashPattern{
+	{regexp.MustCompile(`^\$2[abxy]?\$\d{2}\$[./A-Za-z0-9]{53}$`), []string{"bcrypt"}},
+	{regexp.MustCompile(`^\$argon2id\$`), []string{"argon2id"}},
+	{regexp.MustCompile(`^\$argon2i\$`), []string{"argon2i"}},
+	{regexp.MustCompile(`^\$argon2d\$`), []string{"argon2d"}},
+	{regexp.MustCompile(`^\$1\$[^$]{0,8}\$[./A-Za-z0-9]{22}$`), []string{"md5crypt"}},
+	{regexp.MustCompile(`^\$apr1\$[^$]{0,8}\$[./A-Za-z0-9]{22}$`), []string{"apr1"}},
+	{regexp.MustCompile(`^\$5\$(rounds=\d+\$)?[^$]{0,16}\$[./A-Za-z0-9]{43}$`), []string{"sha256crypt"}},
+	{regexp.MustCompile(`^\$6\$(rounds=\d+\$)?[^$]{0,16}\$[./A-Za-z0-9]{86}$`), []string{"sha512crypt"}},
+	{regexp.MustCompile(`^\$y\$[./A-Za-z0-9]+\$[./A-Za-z0-9]*\$[./A-Za-z0-9]{43}$`), []string{"yescrypt"}},
+	{regexp.MustCompile(`^\$7\$[./A-Za-z0-9]{11,}`), []string{"scrypt"}},
+	{regexp.MustCompile(`^\$[PH]\$[./A-Za-z0-9]{31}$`), []string{"phpass"}},
+	{regexp.MustCompile(`^\$S\$[./A-Za-z0-9]{52}$`), []string{"drupal7"}},
+	{regexp.MustCompile(`^\$pbkdf2-sha(1|256|512)\$`), []string{"pbkdf2"}},
+	{regexp.MustCompile(`^pbkdf2_sha(1|256)\$\d+\$`), []string{"django-pbkdf2"}},
+	{regexp.MustCompile(`^(?i)\$DCC2\$\d+#[^#]+#[a-f0-9]{32}$`), []string{"mscash2"}},
+	{regexp.MustCompile(`^\$krb5tgs\$`), []string{"kerberos-tgs"}},
+	{regexp.MustCompile(`^\$krb5asrep\$`), []string{"kerberos-asrep"}},
+	{regexp.MustCompile(`^\$krb5pa\$`), []string{"kerberos-preauth"}},
+	{regexp.MustCompile(`^\{SHA\}[A-Za-z0-9+/]{27}=$`), []string{"ldap-sha1"}},
+	{regexp.MustCompile(`^\{SSHA\}[A-Za-z0-9+/]{28,}=*$`), []string{"ldap-ssha1"}},
+	{regexp.MustCompile(`^\{SSHA256\}[A-Za-z0-9+/]{44,}=*$`), []string{"ldap-ssha256"}},
+	{regexp.MustCompile(`^\{SSHA512\}[A-Za-z0-9+/]{88,}=*$`), []string{"ldap-ssha512"}},
+	{regexp.MustCompile(`^\{MD5\}[A-Za-z0-9+/]{22}==$`), []string{"ldap-md5"}},
+	{regexp.MustCompile(`^\{SMD5\}[A-Za-z0-9+/]{24,}=*$`), []string{"ldap-smd5"}},
+	{regexp.MustCompile(`^(?i)0x0100[a-f0-9]{48}$`), []string{"mssql2005"}},
+	{regexp.MustCompile(`^(?i)0x0200[a-f0-9]{136}$`), []string{"mssql2012"}},
+	{regexp.MustCompile(`^\*[A-F0-9]{40}$`), []string{"mysql5"}},
+	{regexp.MustCompile(`^(?i)\$NT\$[a-f0-9]{32}$`), []string{"ntlm"}},
+	{regexp.MustCompile(`^[^:]+::[^:]*:(?i)[a-f0-9]{16}:[a-f0-9]{32}:[a-f0-9]+$`), []string{"netntlmv2"}},
+	{regexp.MustCompile(`^[^:]+::[^:]*:(?i)[a-f0-9]{48}:[a-f0-9]{48}:[a-f0-9]{16}$`), []string{"netntlmv1"}},
+	// pwdump格式 user:rid:lm:nt:::
+	{regexp.MustCompile(`^[^:]+:\d+:(?i)[a-f0-9]{32}:[a-f0-9]{32}:::`), []string{"pwdump"}},
+}
+
+// 无前缀hex hash按长度猜测, 按常见程度排序
+var hexHashLengths = map[int][]string{
+	8:   {"crc32", "adler32"},
+	16:  {"mysql323", "crc64", "half-md5"},
+	32:  {"md5", "ntlm", "md4", "lm"},
+	40:  {"sha1", "ripemd160", "mysql5"},
+	48:  {"tiger-192"},
+	56:  {"sha224", "sha3-224", "sha512-224"},
+	64:  {"sha256", "sha3-256", "blake2s-256", "sha512-256"},
+	96:  {"sha384", "sha3-384"},
+	128: {"sha512", "sha3-512", "blake2b-512", "whirlpool"},
+}
+
+// base64形式的摘要按编码后的长度猜测, sha384的48字节摘要编码后恰好64个字符, 没有 = 填充
+var base64HashLengths = map[int][]string{
+	24: {"md5-base64"},
+	28: {"sha1-base64"},
+	44: {"sha256-base64"},
+	64: {"sha384-base64"},
+	88: {"sha512-base64"},
+}
+
+// IdentifyHash 根据前缀, 长度与字符集猜测hash可能的算法(hashid风格), 按可能性从高到低排列, 无法识别时返回nil
+// 结果只是猜测, 例如32位hex同时可能是md5, ntlm与md4
+func IdentifyHash(s string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	for _, p := range hashPatterns {
+		if p.re.MatchString(s) {
+			return append([]string(nil), p.names...)
+		}
+	}
+
+	if isCharset([]byte(s), "0123456789abcdefABCDEF") {
+		names := hexHashLengths[len(s)]
+		// 全大写的32位hex多为Windows导出的LM/NTLM
+		if len(s) == 32 && strings.ToUpper(s) == s && strings.ContainsAny(s, "ABCDEF") {
+			return []string{"ntlm", "lm", "md5", "md4"}
+		}
+		if names != nil {
+			return append([]string(nil), names...)
+		}
+	}
+
+	// hex:salt 形式的加盐hash, 只猜测最常见的算法
+	if i := strings.LastIndexByte(s, ':'); i > 0 && i < len(s)-1 {
+		if names := hexHashLengths[i]; names != nil && isCharset([]byte(s[:i]), "0123456789abcdefABCDEF") {
+			return []string{names[0] + "-salted"}
+		}
+	}
+
+	// 以能否按标准base64严格解码代替对 = 后缀的检查, 填充不正确的字符串会解码失败
+	if names := base64HashLengths[len(s)]; names != nil {
+		if _, err := base64.StdEncoding.Strict().DecodeString(s); err == nil {
+			return append([]string(nil), names...)
+		}
+	}
+	return nil
+}
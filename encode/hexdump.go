@@ -0,0 +1,110 @@
+package encode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HexDump 输出与 hexdump -C 相同格式的 偏移/hex/ASCII 对照, 用于记录二进制banner与协议响应
+func HexDump(data []byte) string {
+	return hex.Dump(data)
+}
+
+// UnHexDumpMaxSize UnHexDump 还原后的最大字节数, * 重复行按偏移填充, 防止伪造的偏移导致分配过大的内存
+var UnHexDumpMaxSize = 64 << 20
+
+// UnHexDump 将hexdump还原为原始字节, 支持 hexdump -C, xxd 以及纯hex字节的格式, 支持 hexdump 中表示重复行的 *
+func UnHexDump(s string) ([]byte, error) {
+	var out, last []byte
+	var repeat, dump bool
+	for n, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.TrimSpace(line) == "*" {
+			repeat = true
+			continue
+		}
+
+		offset, hexPart, hasOffset := splitHexDumpLine(line, dump)
+		dump = dump || hasOffset
+		if hasOffset && offset > UnHexDumpMaxSize {
+			return nil, fmt.Errorf("invalid hexdump at line %d: offset %x exceeds limit %x", n+1, offset, UnHexDumpMaxSize)
+		}
+		if repeat && hasOffset && len(last) > 0 {
+			for len(out) < offset {
+				size := offset - len(out)
+				if size > len(last) {
+					size = len(last)
+				}
+				out = append(out, last[:size]...)
+			}
+		}
+		repeat = false
+
+		var bs []byte
+		for _, field := range strings.Fields(hexPart) {
+			b, err := hex.DecodeString(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hexdump at line %d: %v", n+1, err)
+			}
+			bs = append(bs, b...)
+		}
+		if len(out)+len(bs) > UnHexDumpMaxSize {
+			return nil, fmt.Errorf("invalid hexdump at line %d: size exceeds limit %x", n+1, UnHexDumpMaxSize)
+		}
+		if hasOffset && offset != len(out) && len(bs) > 0 {
+			return nil, fmt.Errorf("invalid hexdump at line %d: offset %x, expect %x", n+1, offset, len(out))
+		}
+		out = append(out, bs...)
+		if len(bs) > 0 {
+			last = bs
+		}
+	}
+	return out, nil
+}
+
+// splitHexDumpLine 拆分出偏移与hex部分, 去掉ASCII列
+// dump表示之前的行带有偏移, 此时只有一列的行视为hexdump结尾的总长度行, 而不是纯hex数据
+func splitHexDumpLine(line string, dump bool) (offset int, hexPart string, hasOffset bool) {
+	line = strings.TrimSpace(line)
+	// hexdump -C 的ASCII列以 | 包围
+	if i := strings.Index(line, "|"); i >= 0 {
+		line = line[:i]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return 0, "", false
+	}
+	first := fields[0]
+	if strings.HasSuffix(first, ":") || len(first) >= 6 && (len(fields) > 1 || dump) {
+		if off, err := strconv.ParseUint(strings.TrimSuffix(first, ":"), 16, 32); err == nil {
+			offset, hasOffset = int(off), true
+			line = strings.TrimSpace(line[len(first):])
+		}
+	}
+	// xxd 的ASCII列与hex部分以两个空格分隔, hexdump -C 的ASCII列已在上面去除
+	if strings.HasSuffix(first, ":") {
+		if i := strings.Index(line, "  "); i >= 0 {
+			line = line[:i]
+		}
+	}
+	return offset, line, hasOffset
+}
+
+func init() {
+	RegisterDSL("hexdump", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(HexDump(data)), nil
+	})
+	RegisterDSL("unhexdump", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := UnHexDump(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("unhexdump: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -0,0 +1,58 @@
+package encode
+
+import (
+	"bytes"
+	"testing"
+)
+
+var hexDumpSample = append(append([]byte("GET / HTTP/1.1\r\nHost: a\r\n\r\n\x00\x01\x02"), make([]byte, 48)...), "end"...)
+
+func TestUnHexDump(t *testing.T) {
+	cases := []struct {
+		name string
+		dump string
+	}{
+		{"hexdump", HexDump(hexDumpSample)},
+		{"hexdump -C", "00000000  47 45 54 20 2f 20 48 54  54 50 2f 31 2e 31 0d 0a  |GET / HTTP/1.1..|\n" +
+			"00000010  48 6f 73 74 3a 20 61 0d  0a 0d 0a 00 01 02 00 00  |Host: a.........|\n" +
+			"00000020  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|\n" +
+			"*\n" +
+			"00000040  00 00 00 00 00 00 00 00  00 00 00 00 00 00 65 6e  |..............en|\n" +
+			"00000050  64                                                |d|\n" +
+			"00000051\n"},
+		{"xxd", "00000000: 4745 5420 2f20 4854 5450 2f31 2e31 0d0a  GET / HTTP/1.1..\n" +
+			"00000010: 486f 7374 3a20 610d 0a0d 0a00 0102 0000  Host: a.........\n" +
+			"00000020: 0000 0000 0000 0000 0000 0000 0000 0000  ................\n" +
+			"00000030: 0000 0000 0000 0000 0000 0000 0000 0000  ................\n" +
+			"00000040: 0000 0000 0000 0000 0000 0000 0000 656e  ..............en\n" +
+			"00000050: 64                                       d\n"},
+		{"xxd -p", "474554202f20485454502f312e310d0a486f73743a20610d0a0d0a000102\n" +
+			"000000000000000000000000000000000000000000000000000000000000\n" +
+			"000000000000000000000000000000000000656e64\n"},
+	}
+	for _, c := range cases {
+		got, err := UnHexDump(c.dump)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if !bytes.Equal(got, hexDumpSample) {
+			t.Errorf("%s: got %q", c.name, got)
+		}
+	}
+}
+
+func TestUnHexDumpLimit(t *testing.T) {
+	got, err := UnHexDump("00000000  41\n*\n00100000")
+	if err != nil || len(got) != 1<<20 {
+		t.Errorf("1MiB repeat: len %d, err %v", len(got), err)
+	}
+	for _, dump := range []string{
+		"00000000  41\n*\nffffffff",
+		"00000000  41\n*\n04000001  41",
+	} {
+		if _, err := UnHexDump(dump); err == nil {
+			t.Errorf("UnHexDump(%q) expect error", dump)
+		}
+	}
+}
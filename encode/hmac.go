@@ -0,0 +1,66 @@
+package encode
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+)
+
+// HMAC系列返回原始字节, 签名时按需使用 HexEncode 或 Base64Encode
+
+func HmacMd5(key, data []byte) []byte {
+	return hmacSum(md5.New, key, data)
+}
+
+func HmacSha1(key, data []byte) []byte {
+	return hmacSum(sha1.New, key, data)
+}
+
+func HmacSha256(key, data []byte) []byte {
+	return hmacSum(sha256.New, key, data)
+}
+
+func HmacSha512(key, data []byte) []byte {
+	return hmacSum(sha512.New, key, data)
+}
+
+func hmacSum(fn func() hash.Hash, key, data []byte) []byte {
+	mac := hmac.New(fn, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// SecureEqual 常量时间比较, 校验签名, token与hash时使用, 避免bytes.Equal提前返回带来的时序侧信道
+// 长度不同时直接返回false, 长度本身不视为秘密
+func SecureEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// SecureEqualString 与 SecureEqual 相同, 用于比较hex或base64形式的签名
+func SecureEqualString(a, b string) bool {
+	return SecureEqual([]byte(a), []byte(b))
+}
+
+func init() {
+	// hmac-sha256:key, key的格式见 ParseKey, 输出hex
+	for name, fn := range map[string]func() hash.Hash{
+		"hmac-md5":    md5.New,
+		"hmac-sha1":   sha1.New,
+		"hmac-sha256": sha256.New,
+		"hmac-sha512": sha512.New,
+	} {
+		name, fn := name, fn
+		RegisterDSL(name, func(data []byte, args ...string) ([]byte, error) {
+			key, err := parseKeyArgs(args)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			return []byte(HexEncode(hmacSum(fn, key, data))), nil
+		})
+	}
+}
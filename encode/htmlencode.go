@@ -0,0 +1,55 @@
+package encode
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+)
+
+// HTMLEncode 转义 < > & ' " 五个字符
+func HTMLEncode(s string) string {
+	return html.EscapeString(s)
+}
+
+// HTMLEncodeNumeric 将每个字符编码为数字实体, hex为true时输出 &#xNN; 否则输出 &#NN;, 用于构造XSS payload变体
+func HTMLEncodeNumeric(s string, hex bool) string {
+	buf := make([]byte, 0, len(s)*6)
+	for _, r := range s {
+		if hex {
+			buf = append(buf, "&#x"...)
+			buf = strconv.AppendInt(buf, int64(r), 16)
+		} else {
+			buf = append(buf, "&#"...)
+			buf = strconv.AppendInt(buf, int64(r), 10)
+		}
+		buf = append(buf, ';')
+	}
+	return string(buf)
+}
+
+// HTMLDecode 解码命名实体(&amp; &nbsp; 等)与数字实体(&#39; &#x27;), 无法识别的实体原样保留
+func HTMLDecode(s string) string {
+	return html.UnescapeString(s)
+}
+
+func init() {
+	// htmlen:dec 与 htmlen:hex 将全部字符编码为数字实体
+	RegisterDSL("htmlen", func(data []byte, args ...string) ([]byte, error) {
+		mode := ""
+		if len(args) > 0 {
+			mode = args[0]
+		}
+		switch mode {
+		case "":
+			return []byte(HTMLEncode(string(data))), nil
+		case "dec":
+			return []byte(HTMLEncodeNumeric(string(data), false)), nil
+		case "hex":
+			return []byte(HTMLEncodeNumeric(string(data), true)), nil
+		}
+		return nil, fmt.Errorf("htmlen: unknown mode %q", mode)
+	})
+	RegisterDSL("htmlde", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(HTMLDecode(string(data))), nil
+	})
+}
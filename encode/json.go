@@ -0,0 +1,89 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONMinify 去除json中的空白, 保留原始的key顺序
+func JSONMinify(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, bytes.TrimSpace(data)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONFormat 以indent缩进格式化json, indent为空时使用两个空格
+func JSONFormat(data []byte, indent string) ([]byte, error) {
+	if indent == "" {
+		indent = "  "
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(data), "", indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONCanonical 输出key排序后的紧凑json, 数字保持原样, 不转义 < > &, 用于稳定的hash与diff
+func JSONCanonical(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// jsonMaxIndent jsonfmt 允许的最大缩进空格数, 缩进会在每一层重复, 过大的值没有意义且会占用大量内存
+const jsonMaxIndent = 16
+
+func init() {
+	RegisterDSL("jsonmin", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := JSONMinify(data)
+		if err != nil {
+			return nil, fmt.Errorf("jsonmin: %v", err)
+		}
+		return bs, nil
+	})
+	// jsonfmt:indent, indent为数字时表示空格数(0~16), 为tab时使用制表符
+	RegisterDSL("jsonfmt", func(data []byte, args ...string) ([]byte, error) {
+		var indent string
+		if len(args) > 0 && args[0] != "" {
+			if args[0] == "tab" {
+				indent = "\t"
+			} else if n, err := strconv.Atoi(args[0]); err == nil && n >= 0 && n <= jsonMaxIndent {
+				indent = strings.Repeat(" ", n)
+			} else {
+				return nil, fmt.Errorf("jsonfmt: invalid indent %q", args[0])
+			}
+		}
+		bs, err := JSONFormat(data, indent)
+		if err != nil {
+			return nil, fmt.Errorf("jsonfmt: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("jsoncanon", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := JSONCanonical(data)
+		if err != nil {
+			return nil, fmt.Errorf("jsoncanon: %v", err)
+		}
+		return bs, nil
+	})
+}
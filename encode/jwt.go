@@ -0,0 +1,107 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JWT 解码后的token, 不校验签名
+type JWT struct {
+	Header    map[string]interface{} `json:"header"`
+	Claims    map[string]interface{} `json:"claims"`
+	Signature []byte                 `json:"-"`
+	Raw       string                 `json:"-"`
+}
+
+// DecodeJWT 解码JWT的header, claims与signature, 不校验签名
+func DecodeJWT(token string) (*JWT, error) {
+	token = strings.TrimSpace(token)
+	token = strings.TrimPrefix(token, "Bearer ")
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid jwt: expect 3 parts, got %d", len(parts))
+	}
+
+	jwt := &JWT{Raw: token}
+	if err := decodeJWTPart(parts[0], &jwt.Header); err != nil {
+		return nil, fmt.Errorf("invalid jwt header: %v", err)
+	}
+	if err := decodeJWTPart(parts[1], &jwt.Claims); err != nil {
+		return nil, fmt.Errorf("invalid jwt claims: %v", err)
+	}
+	sig, err := Base64URLDecodeE(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt signature: %v", err)
+	}
+	jwt.Signature = sig
+	return jwt, nil
+}
+
+func decodeJWTPart(s string, v interface{}) error {
+	bs, err := Base64URLDecodeE(s)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// Alg 返回header中的alg
+func (j *JWT) Alg() string {
+	alg, _ := j.Header["alg"].(string)
+	return alg
+}
+
+// IsNone 是否为 alg=none 的未签名token, 大小写变体(None, NONE)同样视为none
+func (j *JWT) IsNone() bool {
+	return strings.EqualFold(j.Alg(), "none")
+}
+
+// ExpiresAt 返回exp声明, 不存在时ok为false
+func (j *JWT) ExpiresAt() (time.Time, bool) {
+	return j.timeClaim("exp")
+}
+
+// NotBefore 返回nbf声明, 不存在时ok为false
+func (j *JWT) NotBefore() (time.Time, bool) {
+	return j.timeClaim("nbf")
+}
+
+// IsExpired token是否已过期或尚未生效, 没有exp与nbf时返回false
+func (j *JWT) IsExpired() bool {
+	now := time.Now()
+	if exp, ok := j.ExpiresAt(); ok && now.After(exp) {
+		return true
+	}
+	if nbf, ok := j.NotBefore(); ok && now.Before(nbf) {
+		return true
+	}
+	return false
+}
+
+func (j *JWT) timeClaim(name string) (time.Time, bool) {
+	switch v := j.Claims[name].(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return time.Unix(int64(f), 0), true
+		}
+	case float64:
+		return time.Unix(int64(v), 0), true
+	}
+	return time.Time{}, false
+}
+
+func init() {
+	// jwt 将token解码为 {"header":..., "claims":...} 形式的json
+	RegisterDSL("jwt", func(data []byte, args ...string) ([]byte, error) {
+		jwt, err := DecodeJWT(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("jwt: %v", err)
+		}
+		return json.Marshal(jwt)
+	})
+}
@@ -0,0 +1,122 @@
+package encode
+
+import (
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rc4"
+	"fmt"
+)
+
+// DES/3DES/RC4 均已不安全, 仅用于解密遗留系统中的数据
+
+// DesCBCEncrypt iv为nil时随机生成并拼接在密文之前
+func DesCBCEncrypt(data, key, iv []byte) ([]byte, error) {
+	block, err := des.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cbcEncrypt(block, data, iv)
+}
+
+// DesCBCDecrypt iv为nil时取密文的前8字节作为iv
+func DesCBCDecrypt(data, key, iv []byte) ([]byte, error) {
+	block, err := des.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cbcDecrypt(block, data, iv)
+}
+
+func DesECBEncrypt(data, key []byte) ([]byte, error) {
+	block, err := des.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return ecbEncrypt(block, data), nil
+}
+
+func DesECBDecrypt(data, key []byte) ([]byte, error) {
+	block, err := des.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return ecbDecrypt(block, data)
+}
+
+// TripleDesCBCEncrypt key为24字节, 16字节的key按 k1,k2,k1 扩展
+func TripleDesCBCEncrypt(data, key, iv []byte) ([]byte, error) {
+	block, err := newTripleDes(key)
+	if err != nil {
+		return nil, err
+	}
+	return cbcEncrypt(block, data, iv)
+}
+
+func TripleDesCBCDecrypt(data, key, iv []byte) ([]byte, error) {
+	block, err := newTripleDes(key)
+	if err != nil {
+		return nil, err
+	}
+	return cbcDecrypt(block, data, iv)
+}
+
+func TripleDesECBEncrypt(data, key []byte) ([]byte, error) {
+	block, err := newTripleDes(key)
+	if err != nil {
+		return nil, err
+	}
+	return ecbEncrypt(block, data), nil
+}
+
+func TripleDesECBDecrypt(data, key []byte) ([]byte, error) {
+	block, err := newTripleDes(key)
+	if err != nil {
+		return nil, err
+	}
+	return ecbDecrypt(block, data)
+}
+
+func newTripleDes(key []byte) (cipher.Block, error) {
+	if len(key) == 16 {
+		key = append(append(make([]byte, 0, 24), key...), key[:8]...)
+	}
+	return des.NewTripleDESCipher(key)
+}
+
+// RC4 加密与解密相同
+func RC4(data, key []byte) ([]byte, error) {
+	c, err := rc4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(data))
+	c.XORKeyStream(out, data)
+	return out, nil
+}
+
+func init() {
+	registerCipherDSL("des-cbc", DesCBCEncrypt, DesCBCDecrypt)
+	registerCipherDSL("des-ecb", func(data, key, iv []byte) ([]byte, error) {
+		return DesECBEncrypt(data, key)
+	}, func(data, key, iv []byte) ([]byte, error) {
+		return DesECBDecrypt(data, key)
+	})
+	registerCipherDSL("3des-cbc", TripleDesCBCEncrypt, TripleDesCBCDecrypt)
+	registerCipherDSL("3des-ecb", func(data, key, iv []byte) ([]byte, error) {
+		return TripleDesECBEncrypt(data, key)
+	}, func(data, key, iv []byte) ([]byte, error) {
+		return TripleDesECBDecrypt(data, key)
+	})
+	// rc4:key, 加密与解密相同
+	RegisterDSL("rc4", func(data []byte, args ...string) ([]byte, error) {
+		key, err := parseKeyArgs(args)
+		if err != nil {
+			return nil, fmt.Errorf("rc4: %v", err)
+		}
+		bs, err := RC4(data, key)
+		if err != nil {
+			return nil, fmt.Errorf("rc4: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -0,0 +1,68 @@
+package encode
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrDecompressLimit 解压后的数据超过限制, 通常是压缩炸弹
+var ErrDecompressLimit = errors.New("decompressed size exceeds limit")
+
+// DeflateDecompressLimited 解压deflate数据, 输出超过maxOut字节时停止并返回 ErrDecompressLimit
+// 用于解压不可信的响应, 避免压缩炸弹耗尽内存, maxOut<=0时不限制
+func DeflateDecompressLimited(input []byte, maxOut int64) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(input))
+	defer r.Close()
+	return readLimited(r, maxOut)
+}
+
+// GzipDecompressLimited 解压gzip数据, 规则与 DeflateDecompressLimited 相同
+func GzipDecompressLimited(input []byte, maxOut int64) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimited(r, maxOut)
+}
+
+// ZlibDecompressLimited 解压zlib数据, 规则与 DeflateDecompressLimited 相同
+func ZlibDecompressLimited(input []byte, maxOut int64) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimited(r, maxOut)
+}
+
+// ZstdDecompressLimited 解压zstd数据, 规则与 DeflateDecompressLimited 相同
+// 使用流式解码, 不会按帧头中声明的大小预先分配内存
+func ZstdDecompressLimited(input []byte, maxOut int64) ([]byte, error) {
+	d, err := zstd.NewReader(bytes.NewReader(input), zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+	return readLimited(d, maxOut)
+}
+
+func readLimited(r io.Reader, maxOut int64) ([]byte, error) {
+	if maxOut <= 0 {
+		return readAllPooled(r)
+	}
+	data, err := readAllPooled(io.LimitReader(r, maxOut+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxOut {
+		return nil, ErrDecompressLimit
+	}
+	return data, nil
+}
@@ -0,0 +1,75 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MsgpackMarshal 将v序列化为msgpack, 结构体字段可使用 msgpack tag, 未设置时沿用 json tag
+func MsgpackMarshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MsgpackUnmarshal 将msgpack反序列化到v, 与 MsgpackMarshal 对应
+func MsgpackUnmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+// MsgpackToJSON 将任意msgpack数据转换为json, 用于查看未知结构的数据
+// 非字符串的map key会被格式化为字符串, 非utf8的bin会按json的规则输出为base64
+func MsgpackToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := msgpack.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonCompatible(v))
+}
+
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = jsonCompatible(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			m[fmt.Sprint(k)] = jsonCompatible(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonCompatible(item)
+		}
+		return v
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return v
+	}
+	return v
+}
+
+func init() {
+	RegisterDSL("msgpackde", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := MsgpackToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("msgpackde: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -0,0 +1,75 @@
+package encode
+
+import (
+	"crypto/des"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4"
+)
+
+// LMEmptyHash 空密码或超过14个字符时的LM hash
+const LMEmptyHash = "aad3b435b51404eeaad3b435b51404ee"
+
+// NTLMHash 计算NT hash, 即 MD4(UTF-16LE(password)), 以hex返回
+func NTLMHash(password string) string {
+	return HexEncode(NTLMHashBytes(password))
+}
+
+func NTLMHashBytes(password string) []byte {
+	h := md4.New()
+	h.Write(utf16LE(password))
+	return h.Sum(nil)
+}
+
+// LMHash 计算LM hash, 以hex返回. 密码转大写后补齐到14字节, 两个7字节的半段分别作为DES key加密 "KGS!@#$%"
+// 超过14个字符的密码无法生成LM hash, 返回 LMEmptyHash, 与windows的行为一致
+func LMHash(password string) string {
+	if len(password) > 14 {
+		return LMEmptyHash
+	}
+	var key [14]byte
+	copy(key[:], strings.ToUpper(password))
+
+	out := make([]byte, 0, 16)
+	for _, half := range [][]byte{key[:7], key[7:]} {
+		block, _ := des.NewCipher(desKeyFrom7(half))
+		var dst [8]byte
+		block.Encrypt(dst[:], []byte("KGS!@#$%"))
+		out = append(out, dst[:]...)
+	}
+	return HexEncode(out)
+}
+
+// desKeyFrom7 将7字节扩展为8字节的DES key, 每字节高7位为key位, 最低位为校验位(DES会忽略)
+func desKeyFrom7(b []byte) []byte {
+	return []byte{
+		b[0] & 0xfe,
+		b[0]<<7 | b[1]>>1,
+		b[1]<<6 | b[2]>>2,
+		b[2]<<5 | b[3]>>3,
+		b[3]<<4 | b[4]>>4,
+		b[4]<<3 | b[5]>>5,
+		b[5]<<2 | b[6]>>6,
+		b[6] << 1,
+	}
+}
+
+func utf16LE(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	bs := make([]byte, len(units)*2)
+	for i, u := range units {
+		bs[2*i] = byte(u)
+		bs[2*i+1] = byte(u >> 8)
+	}
+	return bs
+}
+
+func init() {
+	RegisterDSL("ntlm", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(NTLMHash(string(data))), nil
+	})
+	RegisterDSL("lm", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(LMHash(string(data))), nil
+	})
+}
@@ -0,0 +1,62 @@
+package encode
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+)
+
+// DeflateDecompressPartial 解压可能被截断的deflate数据, 遇到unexpected EOF时返回此前已解出的全部数据, truncated为true
+// 数据损坏等其他错误会同时返回已解出的数据与错误
+func DeflateDecompressPartial(input []byte) (data []byte, truncated bool, err error) {
+	r := flate.NewReader(bytes.NewReader(input))
+	defer r.Close()
+	return readPartial(r)
+}
+
+// GzipDecompressPartial 解压可能被截断的gzip数据, 用于只抓到部分响应的场景, gzip头不完整时返回错误
+func GzipDecompressPartial(input []byte) (data []byte, truncated bool, err error) {
+	r, err := gzip.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, false, err
+	}
+	defer r.Close()
+	return readPartial(r)
+}
+
+// ZlibDecompressPartial 解压可能被截断的zlib数据, 规则与 DeflateDecompressPartial 相同
+func ZlibDecompressPartial(input []byte) (data []byte, truncated bool, err error) {
+	r, err := zlib.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, false, err
+	}
+	defer r.Close()
+	return readPartial(r)
+}
+
+func readPartial(r io.Reader) ([]byte, bool, error) {
+	data, err := readAllPooled(r)
+	if err == io.ErrUnexpectedEOF {
+		return data, true, nil
+	}
+	return data, false, err
+}
+
+func registerPartialDSL(name string, fn func([]byte) ([]byte, bool, error)) {
+	RegisterDSL(name, func(data []byte, args ...string) ([]byte, error) {
+		bs, _, err := fn(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		return bs, nil
+	})
+}
+
+func init() {
+	registerPartialDSL("inflate-partial", DeflateDecompressPartial)
+	registerPartialDSL("gunzip-partial", GzipDecompressPartial)
+	registerPartialDSL("unzlib-partial", ZlibDecompressPartial)
+}
@@ -0,0 +1,129 @@
+package encode
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHash 生成bcrypt hash, cost<=0时使用 bcrypt.DefaultCost
+func BcryptHash(password []byte, cost int) (string, error) {
+	if cost <= 0 {
+		cost = bcrypt.DefaultCost
+	}
+	bs, err := bcrypt.GenerateFromPassword(password, cost)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// BcryptVerify 校验密码是否与bcrypt hash匹配, 支持 $2a$ $2b$ $2y$ 前缀
+func BcryptVerify(hash string, password []byte) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), password) == nil
+}
+
+// Argon2Params argon2的参数, Memory以KiB为单位
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  int
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params 与RFC 9106第二推荐配置一致
+var DefaultArgon2Params = &Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Argon2idHash 生成PHC格式的argon2id hash, 如 $argon2id$v=19$m=65536,t=3,p=4$salt$hash
+// params为nil时使用 DefaultArgon2Params
+func Argon2idHash(password []byte, params *Argon2Params) (string, error) {
+	if params == nil {
+		params = DefaultArgon2Params
+	}
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(password, salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Argon2MaxMemory Argon2Verify 接受的最大memory参数(KiB), 防止伪造的hash导致分配过大的内存
+var Argon2MaxMemory uint32 = 1024 * 1024
+
+// Argon2MaxIterations Argon2Verify 接受的最大time参数, 防止伪造的hash占用过多CPU
+var Argon2MaxIterations uint32 = 64
+
+// Argon2MaxParallelism Argon2Verify 接受的最大parallelism参数, 限制校验时启动的goroutine数量
+var Argon2MaxParallelism uint8 = 64
+
+// Argon2Verify 校验密码是否与PHC格式的argon2id或argon2i hash匹配, hash格式错误时返回error
+func Argon2Verify(hash string, password []byte) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" {
+		return false, fmt.Errorf("invalid argon2 hash format")
+	}
+	variant := parts[1]
+	if variant != "argon2id" && variant != "argon2i" {
+		return false, fmt.Errorf("unsupported argon2 variant %q", variant)
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, fmt.Errorf("invalid argon2 version %q", parts[2])
+	}
+	if version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, fmt.Errorf("invalid argon2 params %q", parts[3])
+	}
+	// argon2包对非法参数直接panic, 校验来历不明的hash时需要提前检查
+	if iterations < 1 || parallelism < 1 {
+		return false, fmt.Errorf("invalid argon2 params %q", parts[3])
+	}
+	if memory > Argon2MaxMemory {
+		return false, fmt.Errorf("argon2 memory %d KiB exceeds limit %d KiB", memory, Argon2MaxMemory)
+	}
+	if iterations > Argon2MaxIterations {
+		return false, fmt.Errorf("argon2 iterations %d exceeds limit %d", iterations, Argon2MaxIterations)
+	}
+	if parallelism > Argon2MaxParallelism {
+		return false, fmt.Errorf("argon2 parallelism %d exceeds limit %d", parallelism, Argon2MaxParallelism)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2 salt: %v", err)
+	}
+	expect, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2 hash: %v", err)
+	}
+	if len(expect) == 0 {
+		return false, fmt.Errorf("empty argon2 hash")
+	}
+
+	var key []byte
+	if variant == "argon2id" {
+		key = argon2.IDKey(password, salt, iterations, memory, parallelism, uint32(len(expect)))
+	} else {
+		key = argon2.Key(password, salt, iterations, memory, parallelism, uint32(len(expect)))
+	}
+	return SecureEqual(key, expect), nil
+}
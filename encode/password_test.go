@@ -0,0 +1,56 @@
+package encode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBcrypt(t *testing.T) {
+	hash, err := BcryptHash([]byte("admin"), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$2a$04$") {
+		t.Errorf("BcryptHash = %s", hash)
+	}
+	if !BcryptVerify(hash, []byte("admin")) || BcryptVerify(hash, []byte("admin1")) {
+		t.Errorf("BcryptVerify mismatch for %s", hash)
+	}
+	if BcryptVerify("$2a$04$broken", []byte("admin")) {
+		t.Errorf("BcryptVerify accepted a malformed hash")
+	}
+}
+
+func TestArgon2(t *testing.T) {
+	params := &Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 8, KeyLength: 16}
+	hash, err := Argon2idHash([]byte("admin"), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := Argon2Verify(hash, []byte("admin")); !ok || err != nil {
+		t.Errorf("Argon2Verify(%s) = %v, %v", hash, ok, err)
+	}
+	if ok, err := Argon2Verify(hash, []byte("admin1")); ok || err != nil {
+		t.Errorf("Argon2Verify wrong password = %v, %v", ok, err)
+	}
+
+	// 格式错误或参数非法的hash应返回错误而不是panic
+	malformed := []string{
+		"",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA",
+		"$argon2id$v=19$m=1024,t=0,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=1024,t=1,p=0$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$",
+		"$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=1024,t=4294967295,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=1024,t=1,p=255$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2d$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!$aGFzaGhhc2hoYXNoaGFzaA",
+	}
+	for _, hash := range malformed {
+		if ok, err := Argon2Verify(hash, []byte("admin")); ok || err == nil {
+			t.Errorf("Argon2Verify(%q) = %v, %v; want error", hash, ok, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package encode
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// idnaDotReplacer 将全角句号等IDNA中的分隔符替换为 .
+var idnaDotReplacer = strings.NewReplacer("。", ".", "．", ".", "｡", ".")
+
+// ToPunycode 将国际化域名逐个label转换为 xn-- 形式, 纯ASCII的label保持不变
+// 只做转换不做IDNA合法性校验, 以便处理证书与目标列表中不规范的域名
+func ToPunycode(domain string) (string, error) {
+	labels := strings.Split(idnaDotReplacer.Replace(domain), ".")
+	for i, label := range labels {
+		s, err := idna.Punycode.ToASCII(strings.ToLower(label))
+		if err != nil {
+			return "", fmt.Errorf("label %q: %v", label, err)
+		}
+		labels[i] = s
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// FromPunycode 将域名中 xn-- 开头的label还原为unicode, 其余label保持不变
+func FromPunycode(domain string) (string, error) {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		label = strings.ToLower(label)
+		if !strings.HasPrefix(label, "xn--") {
+			continue
+		}
+		s, err := idna.Punycode.ToUnicode(label)
+		if err != nil {
+			return "", fmt.Errorf("label %q: %v", label, err)
+		}
+		labels[i] = s
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func init() {
+	RegisterDSL("punyen", func(data []byte, args ...string) ([]byte, error) {
+		s, err := ToPunycode(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("punyen: %v", err)
+		}
+		return []byte(s), nil
+	})
+	RegisterDSL("punyde", func(data []byte, args ...string) ([]byte, error) {
+		s, err := FromPunycode(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("punyde: %v", err)
+		}
+		return []byte(s), nil
+	})
+}
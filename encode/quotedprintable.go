@@ -0,0 +1,43 @@
+package encode
+
+import (
+	"bytes"
+	"fmt"
+	"mime/quotedprintable"
+	"strings"
+)
+
+// QuotedPrintableEncode 按RFC 2045编码, 行宽不超过76, 换行统一为CRLF
+func QuotedPrintableEncode(b []byte) (string, error) {
+	var buf bytes.Buffer
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// QuotedPrintableDecode 解码quoted-printable, 会去除软换行(=\r\n), 兼容小写hex
+func QuotedPrintableDecode(s string) ([]byte, error) {
+	return readAllPooled(quotedprintable.NewReader(strings.NewReader(s)))
+}
+
+func init() {
+	RegisterDSL("qpen", func(data []byte, args ...string) ([]byte, error) {
+		s, err := QuotedPrintableEncode(data)
+		if err != nil {
+			return nil, fmt.Errorf("qpen: %v", err)
+		}
+		return []byte(s), nil
+	})
+	RegisterDSL("qpde", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := QuotedPrintableDecode(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("qpde: %v", err)
+		}
+		return bs, nil
+	})
+}
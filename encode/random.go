@@ -0,0 +1,76 @@
+package encode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chainreactors/utils/iutils"
+)
+
+// 随机生成类操作符将生成的值追加到输入之后, 如 "randhex:8|id=" 生成 "id=" 加8位随机hex, 输入为空时即为随机值本身
+// 随机源均为crypto/rand, 用于canary, boundary等不可预测的值, 随机源不可用时返回error
+
+func randLength(op string, args []string) (int, error) {
+	if len(args) == 0 || args[0] == "" {
+		return 0, fmt.Errorf("%s: missing length", op)
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s: invalid length %q", op, args[0])
+	}
+	// 长度来自用户输入, 与解压一样以 AutoDecodeMaxSize 为上限, 避免分配失败导致panic
+	if int64(n) > AutoDecodeMaxSize {
+		return 0, fmt.Errorf("%s: length %d exceeds limit %d", op, n, AutoDecodeMaxSize)
+	}
+	return n, nil
+}
+
+// appendRand 将随机值拼接在data之后, 使用新的切片, 不修改上一阶段的输出
+func appendRand(data, rand []byte) []byte {
+	out := make([]byte, 0, len(data)+len(rand))
+	out = append(out, data...)
+	return append(out, rand...)
+}
+
+func init() {
+	// rand:n, n个随机字节
+	RegisterDSL("rand", func(data []byte, args ...string) ([]byte, error) {
+		n, err := randLength("rand", args)
+		if err != nil {
+			return nil, err
+		}
+		bs, err := iutils.RandomBytes(n)
+		if err != nil {
+			return nil, fmt.Errorf("rand: %v", err)
+		}
+		return appendRand(data, bs), nil
+	})
+	// randhex:n, n个随机hex字符
+	RegisterDSL("randhex", func(data []byte, args ...string) ([]byte, error) {
+		n, err := randLength("randhex", args)
+		if err != nil {
+			return nil, err
+		}
+		s, err := iutils.RandomHex(n)
+		if err != nil {
+			return nil, fmt.Errorf("randhex: %v", err)
+		}
+		return appendRand(data, []byte(s)), nil
+	})
+	// randstr:n:charset, charset缺省为字母与数字, 其中的 : , | 需要使用 \ 转义
+	RegisterDSL("randstr", func(data []byte, args ...string) ([]byte, error) {
+		n, err := randLength("randstr", args)
+		if err != nil {
+			return nil, err
+		}
+		var charset string
+		if len(args) > 1 {
+			charset = args[1]
+		}
+		s, err := iutils.RandomString(n, charset)
+		if err != nil {
+			return nil, fmt.Errorf("randstr: %v", err)
+		}
+		return appendRand(data, []byte(s)), nil
+	})
+}
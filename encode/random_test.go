@@ -0,0 +1,67 @@
+package encode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRandomDSL(t *testing.T) {
+	cases := []struct {
+		spec    string
+		prefix  string
+		length  int
+		charset string
+	}{
+		{"rand:16|", "", 16, ""},
+		{"rand:0|id=", "id=", 0, ""},
+		{"randhex:7|id=", "id=", 7, "0123456789abcdef"},
+		{"randhex:32|", "", 32, "0123456789abcdef"},
+		{"randstr:20|", "", 20, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"},
+		{"randstr:50:ab|x", "x", 50, "ab"},
+		{"randstr:30:a\\:b|", "", 30, "a:b"},
+	}
+	for _, c := range cases {
+		out, err := DSLParserE(c.spec)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if !bytes.HasPrefix(out, []byte(c.prefix)) || len(out) != len(c.prefix)+c.length {
+			t.Errorf("%s: got %q", c.spec, out)
+			continue
+		}
+		if c.charset == "" {
+			continue
+		}
+		for _, b := range out[len(c.prefix):] {
+			if !strings.ContainsRune(c.charset, rune(b)) {
+				t.Errorf("%s: %q not in charset %q", c.spec, b, c.charset)
+				break
+			}
+		}
+	}
+
+	// 随机值拼接在新的切片上, 不能覆盖上一阶段输出的底层数组
+	prev := make([]byte, 3, 64)
+	copy(prev, "id=")
+	fn, _ := GetDSL("randhex")
+	first, _ := fn(prev, "8")
+	second, _ := fn(prev, "8")
+	if bytes.Equal(first, second) {
+		t.Errorf("randhex reused the input buffer: %q %q", first, second)
+	}
+
+	a, _ := DSLParserE("randhex:32|")
+	b, _ := DSLParserE("randhex:32|")
+	if bytes.Equal(a, b) {
+		t.Errorf("randhex returned the same value twice: %q", a)
+	}
+
+	for _, spec := range []string{"rand|", "rand:-1|", "randhex:x|", "randstr|",
+		"rand:9223372036854775807|", "randhex:9223372036854775807|", "randstr:9223372036854775807|"} {
+		if _, err := DSLParserE(spec); err == nil {
+			t.Errorf("%s: expect error", spec)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package encode
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+var ErrNotRSAKey = errors.New("not an rsa key")
+
+// ParsePublicKey 解析RSA公钥, 支持PEM与DER, 以及PKIX(PUBLIC KEY), PKCS1(RSA PUBLIC KEY)与证书
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		der = block.Bytes
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		if pub, ok := key.(*rsa.PublicKey); ok {
+			return pub, nil
+		}
+		return nil, ErrNotRSAKey
+	}
+	if pub, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return pub, nil
+	}
+	if cert, err := x509.ParseCertificate(der); err == nil {
+		if pub, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			return pub, nil
+		}
+		return nil, ErrNotRSAKey
+	}
+	return nil, fmt.Errorf("unable to parse public key")
+}
+
+// ParsePrivateKey 解析RSA私钥, 支持PEM与DER, 以及PKCS1(RSA PRIVATE KEY)与PKCS8(PRIVATE KEY)
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		der = block.Bytes
+	}
+
+	if priv, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return priv, nil
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		if priv, ok := key.(*rsa.PrivateKey); ok {
+			return priv, nil
+		}
+		return nil, ErrNotRSAKey
+	}
+	return nil, fmt.Errorf("unable to parse private key")
+}
+
+// RsaEncryptPKCS1v15 使用PKCS1v15填充加密, 超过单块长度的数据按 k-11 字节分段加密后拼接, 与常见的java分段加密实现一致
+func RsaEncryptPKCS1v15(pub *rsa.PublicKey, data []byte) ([]byte, error) {
+	return rsaChunk(data, pub.Size()-11, func(chunk []byte) ([]byte, error) {
+		return rsa.EncryptPKCS1v15(rand.Reader, pub, chunk)
+	})
+}
+
+// RsaDecryptPKCS1v15 解密PKCS1v15填充的数据, 支持分段加密的密文
+func RsaDecryptPKCS1v15(priv *rsa.PrivateKey, data []byte) ([]byte, error) {
+	if len(data) == 0 || len(data)%priv.Size() != 0 {
+		return nil, fmt.Errorf("ciphertext length %d is not a multiple of key size %d", len(data), priv.Size())
+	}
+	return rsaChunk(data, priv.Size(), func(chunk []byte) ([]byte, error) {
+		return rsa.DecryptPKCS1v15(rand.Reader, priv, chunk)
+	})
+}
+
+// RsaEncryptOAEP 使用OAEP填充加密, hash通常为 crypto.SHA1 或 crypto.SHA256, 同样支持分段
+func RsaEncryptOAEP(pub *rsa.PublicKey, data []byte, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash %v unavailable", hash)
+	}
+	return rsaChunk(data, pub.Size()-2*hash.Size()-2, func(chunk []byte) ([]byte, error) {
+		return rsa.EncryptOAEP(hash.New(), rand.Reader, pub, chunk, nil)
+	})
+}
+
+func RsaDecryptOAEP(priv *rsa.PrivateKey, data []byte, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash %v unavailable", hash)
+	}
+	if len(data) == 0 || len(data)%priv.Size() != 0 {
+		return nil, fmt.Errorf("ciphertext length %d is not a multiple of key size %d", len(data), priv.Size())
+	}
+	return rsaChunk(data, priv.Size(), func(chunk []byte) ([]byte, error) {
+		return rsa.DecryptOAEP(hash.New(), rand.Reader, priv, chunk, nil)
+	})
+}
+
+// RsaSign 对data计算hash后使用PKCS1v15签名
+func RsaSign(priv *rsa.PrivateKey, data []byte, hash crypto.Hash) ([]byte, error) {
+	digest, err := hashDigest(data, hash)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.SignPKCS1v15(rand.Reader, priv, hash, digest)
+}
+
+// RsaVerify 校验PKCS1v15签名, 签名不匹配时返回error
+func RsaVerify(pub *rsa.PublicKey, data, sig []byte, hash crypto.Hash) error {
+	digest, err := hashDigest(data, hash)
+	if err != nil {
+		return err
+	}
+	return rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+}
+
+func hashDigest(data []byte, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash %v unavailable", hash)
+	}
+	h := hash.New()
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// rsaChunk 按size分段处理data, 空数据同样处理一次, 使空明文加密为一个完整的块而不是空密文
+func rsaChunk(data []byte, size int, fn func([]byte) ([]byte, error)) ([]byte, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("key too small")
+	}
+	out := make([]byte, 0, len(data))
+	for first := true; first || len(data) > 0; first = false {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		bs, err := fn(data[:n])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, bs...)
+		data = data[n:]
+	}
+	return out, nil
+}
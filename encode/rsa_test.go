@@ -0,0 +1,85 @@
+package encode
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestRsa(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	pkix, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	privs := [][]byte{
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		pkcs8,
+	}
+	pubs := [][]byte{
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}),
+		pkix,
+	}
+
+	// 300字节超过单块长度, 覆盖分段加密
+	plains := [][]byte{nil, []byte("admin"), bytes.Repeat([]byte("0123456789"), 30)}
+	for i := range privs {
+		priv, err := ParsePrivateKey(privs[i])
+		if err != nil {
+			t.Fatalf("ParsePrivateKey #%d: %v", i, err)
+		}
+		pub, err := ParsePublicKey(pubs[i])
+		if err != nil {
+			t.Fatalf("ParsePublicKey #%d: %v", i, err)
+		}
+
+		for _, plain := range plains {
+			enc, err := RsaEncryptPKCS1v15(pub, plain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunks := (len(plain) + pub.Size() - 12) / (pub.Size() - 11)
+			if chunks == 0 {
+				chunks = 1
+			}
+			if len(enc) != chunks*pub.Size() {
+				t.Errorf("PKCS1v15 ciphertext length %d for %d bytes", len(enc), len(plain))
+			}
+			dec, err := RsaDecryptPKCS1v15(priv, enc)
+			if err != nil || !bytes.Equal(dec, plain) {
+				t.Errorf("PKCS1v15 round trip %q: %q, %v", plain, dec, err)
+			}
+
+			enc, err = RsaEncryptOAEP(pub, plain, crypto.SHA256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dec, err = RsaDecryptOAEP(priv, enc, crypto.SHA256)
+			if err != nil || !bytes.Equal(dec, plain) {
+				t.Errorf("OAEP round trip %q: %q, %v", plain, dec, err)
+			}
+		}
+
+		sig, err := RsaSign(priv, []byte("admin"), crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := RsaVerify(pub, []byte("admin"), sig, crypto.SHA256); err != nil {
+			t.Errorf("RsaVerify: %v", err)
+		}
+		if err := RsaVerify(pub, []byte("admin1"), sig, crypto.SHA256); err == nil {
+			t.Errorf("RsaVerify accepted a signature for different data")
+		}
+	}
+
+	if _, err := RsaDecryptPKCS1v15(key, nil); err == nil {
+		t.Errorf("RsaDecryptPKCS1v15 accepted empty ciphertext")
+	}
+}
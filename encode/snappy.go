@@ -0,0 +1,104 @@
+package encode
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/pierrec/lz4/v4"
+)
+
+// snappy与lz4以块格式压缩, 压缩率低于deflate, 但CPU开销小得多, 适合扫描中间数据
+
+// SnappyEncode 以snappy块格式压缩
+func SnappyEncode(input []byte) []byte {
+	return snappy.Encode(nil, input)
+}
+
+// SnappyDecode 解压snappy块格式数据
+func SnappyDecode(input []byte) ([]byte, error) {
+	return snappy.Decode(nil, input)
+}
+
+// lz4块格式不记录原始长度, 与python lz4.block(store_size=True)一致, 以4字节小端长度作为前缀
+const lz4MaxRatio = 255
+
+// LZ4Compress 以lz4块格式压缩, 输出前4字节为原始长度
+func LZ4Compress(input []byte) ([]byte, error) {
+	dst := make([]byte, 4+lz4.CompressBlockBound(len(input)))
+	binary.LittleEndian.PutUint32(dst, uint32(len(input)))
+	if len(input) == 0 {
+		return dst[:4], nil
+	}
+	n, err := lz4.CompressBlock(input, dst[4:], nil)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:4+n], nil
+}
+
+// LZ4Decompress 解压带长度前缀的lz4块数据
+func LZ4Decompress(input []byte) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, fmt.Errorf("lz4: input too short")
+	}
+	size := int(binary.LittleEndian.Uint32(input))
+	// lz4的压缩率存在理论上限, 超过上限的长度前缀必然是错误数据, 避免据此分配巨大内存
+	// 32位平台上超过MaxInt32的长度前缀转换为int后为负数
+	if size < 0 || size > (len(input)-4)*lz4MaxRatio+16 {
+		return nil, fmt.Errorf("lz4: invalid size prefix %d", size)
+	}
+	dst := make([]byte, size)
+	if size == 0 {
+		return dst, nil
+	}
+	n, err := lz4.UncompressBlock(input[4:], dst)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:n], nil
+}
+
+// NewSnappyWriter 返回snappy流格式(framing format)压缩的writer
+func NewSnappyWriter(w io.Writer) io.WriteCloser {
+	return snappy.NewBufferedWriter(w)
+}
+
+// NewSnappyReader 返回snappy流格式解压的reader
+func NewSnappyReader(r io.Reader) io.Reader {
+	return snappy.NewReader(r)
+}
+
+// NewLZ4Writer 返回lz4帧格式压缩的writer
+func NewLZ4Writer(w io.Writer) io.WriteCloser {
+	return lz4.NewWriter(w)
+}
+
+// NewLZ4Reader 返回lz4帧格式解压的reader
+func NewLZ4Reader(r io.Reader) io.Reader {
+	return lz4.NewReader(r)
+}
+
+func init() {
+	RegisterDSL("snappy", func(data []byte, args ...string) ([]byte, error) {
+		return SnappyEncode(data), nil
+	})
+	RegisterDSL("unsnappy", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := SnappyDecode(data)
+		if err != nil {
+			return nil, fmt.Errorf("unsnappy: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("lz4", func(data []byte, args ...string) ([]byte, error) {
+		return LZ4Compress(data)
+	})
+	RegisterDSL("unlz4", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := LZ4Decompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("unlz4: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -0,0 +1,115 @@
+package encode
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// 流式编解码, 用于处理大文件或网络流, 避免将完整数据读入内存
+
+// NewBase64Writer 返回base64编码的writer, 必须调用Close写入剩余数据
+func NewBase64Writer(w io.Writer) io.WriteCloser {
+	return base64.NewEncoder(base64.StdEncoding, w)
+}
+
+// NewBase64Reader 返回base64解码的reader
+func NewBase64Reader(r io.Reader) io.Reader {
+	return base64.NewDecoder(base64.StdEncoding, r)
+}
+
+// NewHexWriter 返回hex编码的writer
+func NewHexWriter(w io.Writer) io.WriteCloser {
+	return nopWriteCloser{hex.NewEncoder(w)}
+}
+
+// NewHexReader 返回hex解码的reader
+func NewHexReader(r io.Reader) io.Reader {
+	return hex.NewDecoder(r)
+}
+
+// NewDeflateWriter 返回deflate压缩的writer, Close时写入结束块
+func NewDeflateWriter(w io.Writer) io.WriteCloser {
+	fw, _ := flate.NewWriter(w, flate.BestCompression)
+	return fw
+}
+
+// NewDeflateReader 返回deflate解压的reader
+func NewDeflateReader(r io.Reader) io.ReadCloser {
+	return flate.NewReader(r)
+}
+
+// NewGzipWriter 返回gzip压缩的writer, 必须调用Close写入尾部校验
+func NewGzipWriter(w io.Writer) io.WriteCloser {
+	return gzip.NewWriter(w)
+}
+
+// NewGzipReader 返回gzip解压的reader, 读取gzip头失败时返回错误
+func NewGzipReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// NewEncodeWriter 按名称返回编码/压缩的writer, 支持 base64, hex, deflate, gzip, zstd, snappystream, lz4frame
+// snappystream与lz4frame为流格式, 与DSL中块格式的 snappy, lz4 不兼容
+func NewEncodeWriter(name string, w io.Writer) (io.WriteCloser, error) {
+	switch name {
+	case "base64", "b64en":
+		return NewBase64Writer(w), nil
+	case "hex":
+		return NewHexWriter(w), nil
+	case "deflate":
+		return NewDeflateWriter(w), nil
+	case "gzip":
+		return NewGzipWriter(w), nil
+	case "zstd":
+		return NewZstdWriter(w)
+	case "snappystream":
+		return NewSnappyWriter(w), nil
+	case "lz4frame":
+		return NewLZ4Writer(w), nil
+	}
+	return nil, fmt.Errorf("unsupported stream encoder %q", name)
+}
+
+// NewDecodeReader 按名称返回解码/解压的reader, 支持 base64, hex, deflate, gzip, zstd, brotli, snappystream, lz4frame
+func NewDecodeReader(name string, r io.Reader) (io.ReadCloser, error) {
+	switch name {
+	case "base64", "b64de":
+		return ioutil.NopCloser(NewBase64Reader(r)), nil
+	case "hex", "unhex":
+		return ioutil.NopCloser(NewHexReader(r)), nil
+	case "deflate", "inflate":
+		return NewDeflateReader(r), nil
+	case "gzip", "gunzip":
+		return NewGzipReader(r)
+	case "zstd", "unzstd":
+		return NewZstdReader(r)
+	case "br", "brotli":
+		return ioutil.NopCloser(NewBrotliReader(r)), nil
+	case "snappystream":
+		return ioutil.NopCloser(NewSnappyReader(r)), nil
+	case "lz4frame":
+		return ioutil.NopCloser(NewLZ4Reader(r)), nil
+	case "bzip2", "bunzip2":
+		return ioutil.NopCloser(NewBzip2Reader(r)), nil
+	case "xz", "unxz":
+		xr, err := NewXzReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(xr), nil
+	}
+	return nil, fmt.Errorf("unsupported stream decoder %q", name)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
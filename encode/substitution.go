@@ -0,0 +1,77 @@
+package encode
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Rot13 对字母做13位移位, 其余字符不变, 编码与解码相同
+func Rot13(b []byte) []byte {
+	return Caesar(b, 13)
+}
+
+// Caesar 对字母做n位移位, n可以为负数, 保留大小写, 其余字符不变
+func Caesar(b []byte, n int) []byte {
+	n %= 26
+	if n < 0 {
+		n += 26
+	}
+	out := make([]byte, len(b))
+	for i, c := range b {
+		switch {
+		case 'a' <= c && c <= 'z':
+			c = 'a' + (c-'a'+byte(n))%26
+		case 'A' <= c && c <= 'Z':
+			c = 'A' + (c-'A'+byte(n))%26
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// Substitute 按字节做单表替换, from中的第i个字节替换为to中的第i个字节, from与to长度必须一致
+func Substitute(b []byte, from, to string) ([]byte, error) {
+	if len(from) != len(to) {
+		return nil, fmt.Errorf("substitution table length mismatch: %d != %d", len(from), len(to))
+	}
+	var table [256]byte
+	for i := range table {
+		table[i] = byte(i)
+	}
+	for i := 0; i < len(from); i++ {
+		table[from[i]] = to[i]
+	}
+	out := make([]byte, len(b))
+	for i, c := range b {
+		out[i] = table[c]
+	}
+	return out, nil
+}
+
+func init() {
+	RegisterDSL("rot13", func(data []byte, args ...string) ([]byte, error) {
+		return Rot13(data), nil
+	})
+	// caesar:n, 解码时使用负数
+	RegisterDSL("caesar", func(data []byte, args ...string) ([]byte, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("caesar: missing shift")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("caesar: invalid shift %q", args[0])
+		}
+		return Caesar(data, n), nil
+	})
+	// tr:from:to, 例如 tr:abc:xyz
+	RegisterDSL("tr", func(data []byte, args ...string) ([]byte, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("tr: expect from and to")
+		}
+		bs, err := Substitute(data, args[0], args[1])
+		if err != nil {
+			return nil, fmt.Errorf("tr: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -0,0 +1,27 @@
+package encode
+
+import (
+	"github.com/chainreactors/utils/iutils"
+)
+
+// registerDSLTemplate 将DSL操作符注册为模板函数, 最后一个参数为输入, 其余为操作符参数
+// 例如 {{b64en(admin)}}, {{xor(6b, admin)}}
+func registerDSLTemplate(name string) {
+	if _, ok := iutils.GetTemplateFunc(name); ok {
+		return
+	}
+	iutils.RegisterTemplateFunc(name, func(args ...string) (string, error) {
+		fn, ok := GetDSL(name)
+		if !ok {
+			return "", ErrUnknownDSL
+		}
+		if len(args) == 0 {
+			return "", nil
+		}
+		bs, err := fn([]byte(args[len(args)-1]), args[:len(args)-1]...)
+		if err != nil {
+			return "", err
+		}
+		return string(bs), nil
+	})
+}
@@ -0,0 +1,103 @@
+package encode
+
+import (
+	"fmt"
+	"github.com/chainreactors/utils/iutils"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UnicodeEscape 将字符转义为 \uXXXX, all为false时只转义非ASCII字符, 超出BMP的字符转义为代理对, 与JSON一致
+func UnicodeEscape(s string, all bool) string {
+	return unicodeEscape(s, `\u`, all)
+}
+
+// UnicodeEscapePercent 将全部字符转义为 %uXXXX, 常见于IIS与WAF绕过payload
+func UnicodeEscapePercent(s string) string {
+	return unicodeEscape(s, "%u", true)
+}
+
+func unicodeEscape(s, prefix string, all bool) string {
+	buf := make([]byte, 0, len(s)*6)
+	for _, r := range s {
+		if !all && r < utf8.RuneSelf {
+			buf = append(buf, byte(r))
+			continue
+		}
+		if r > 0xffff {
+			r1, r2 := utf16.EncodeRune(r)
+			buf = appendUnicodeEscape(buf, prefix, r1)
+			buf = appendUnicodeEscape(buf, prefix, r2)
+			continue
+		}
+		buf = appendUnicodeEscape(buf, prefix, r)
+	}
+	return string(buf)
+}
+
+func appendUnicodeEscape(buf []byte, prefix string, r rune) []byte {
+	buf = append(buf, prefix...)
+	return append(buf, iutils.HexChars[r>>12&15], iutils.HexChars[r>>8&15], iutils.HexChars[r>>4&15], iutils.HexChars[r&15])
+}
+
+// UnicodeUnescape 解码 \uXXXX 与 %uXXXX 转义, 支持代理对, 非法的转义原样保留
+func UnicodeUnescape(s string) string {
+	buf := make([]byte, 0, len(s))
+	for i := 0; i < len(s); {
+		r, n := parseUnicodeEscape(s[i:])
+		if n == 0 {
+			buf = append(buf, s[i])
+			i++
+			continue
+		}
+		if utf16.IsSurrogate(r) {
+			if r2, n2 := parseUnicodeEscape(s[i+n:]); n2 > 0 {
+				if dec := utf16.DecodeRune(r, r2); dec != utf8.RuneError {
+					buf = append(buf, string(dec)...)
+					i += n + n2
+					continue
+				}
+			}
+		}
+		buf = append(buf, string(r)...)
+		i += n
+	}
+	return string(buf)
+}
+
+// parseUnicodeEscape 解析开头的 \uXXXX 或 %uXXXX, 返回rune与消耗的字节数, 不匹配时返回0
+func parseUnicodeEscape(s string) (rune, int) {
+	if len(s) < 6 || (s[0] != '\\' && s[0] != '%') || (s[1] != 'u' && s[1] != 'U') {
+		return 0, 0
+	}
+	var r rune
+	for i := 2; i < 6; i++ {
+		if !isHex(s[i]) {
+			return 0, 0
+		}
+		r = r<<4 | rune(unhex(s[i]))
+	}
+	return r, 6
+}
+
+func init() {
+	// uen:all 转义全部字符, uen:percent 输出 %uXXXX, 默认只转义非ASCII字符
+	RegisterDSL("uen", func(data []byte, args ...string) ([]byte, error) {
+		mode := ""
+		if len(args) > 0 {
+			mode = args[0]
+		}
+		switch mode {
+		case "":
+			return []byte(UnicodeEscape(string(data), false)), nil
+		case "all":
+			return []byte(UnicodeEscape(string(data), true)), nil
+		case "percent":
+			return []byte(UnicodeEscapePercent(string(data))), nil
+		}
+		return nil, fmt.Errorf("uen: unknown mode %q", mode)
+	})
+	RegisterDSL("ude", func(data []byte, args ...string) ([]byte, error) {
+		return []byte(UnicodeUnescape(string(data))), nil
+	})
+}
@@ -0,0 +1,142 @@
+package encode
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const upperHex = "0123456789ABCDEF"
+
+// URLEncode 按 encodeURIComponent 的规则编码, 仅保留 A-Z a-z 0-9 - _ . ~
+func URLEncode(s string) string {
+	return urlEscape(s, isUnreserved)
+}
+
+// URLEncodeFull 编码完整的URL, 保留URL中的保留字符(: / ? # [ ] @ ! $ & ' ( ) * + , ; = %)
+func URLEncodeFull(s string) string {
+	return urlEscape(s, func(c byte) bool {
+		if isUnreserved(c) {
+			return true
+		}
+		switch c {
+		case ':', '/', '?', '#', '[', ']', '@', '!', '$', '&', '\'', '(', ')', '*', '+', ',', ';', '=', '%':
+			return true
+		}
+		return false
+	})
+}
+
+// URLEncodeAll 将每个字节都编码为 %XX, 常用于构造绕过WAF的payload
+func URLEncodeAll(s string) string {
+	return urlEscape(s, func(c byte) bool {
+		return false
+	})
+}
+
+// URLDecode 解码 %XX, 非法的转义序列原样保留, 不会返回错误; form为true时将 + 解码为空格
+func URLDecode(s string, form bool) string {
+	var buf []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			if buf == nil {
+				buf = append(make([]byte, 0, len(s)), s[:i]...)
+			}
+			buf = append(buf, unhex(s[i+1])<<4|unhex(s[i+2]))
+			i += 2
+			continue
+		case c == '+' && form:
+			c = ' '
+			if buf == nil {
+				buf = append(make([]byte, 0, len(s)), s[:i]...)
+			}
+		}
+		if buf != nil {
+			buf = append(buf, c)
+		}
+	}
+	if buf == nil {
+		return s
+	}
+	return string(buf)
+}
+
+// URLDecodeN 重复解码最多n次, 直到结果不再变化, 用于处理双重编码, n<=0时解码到稳定为止
+func URLDecodeN(s string, n int, form bool) string {
+	for i := 0; n <= 0 || i < n; i++ {
+		decoded := URLDecode(s, form)
+		if decoded == s {
+			break
+		}
+		s = decoded
+	}
+	return s
+}
+
+func urlEscape(s string, keep func(byte) bool) string {
+	buf := make([]byte, 0, len(s)*3)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if keep(c) {
+			buf = append(buf, c)
+			continue
+		}
+		buf = append(buf, '%', upperHex[c>>4], upperHex[c&15])
+	}
+	return string(buf)
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	return c - 'A' + 10
+}
+
+func init() {
+	// urlencode:url 编码完整URL, urlencode:all 编码全部字节, 默认按URL组件编码
+	RegisterDSL("urlencode", func(data []byte, args ...string) ([]byte, error) {
+		mode := ""
+		if len(args) > 0 {
+			mode = args[0]
+		}
+		switch mode {
+		case "":
+			return []byte(URLEncode(string(data))), nil
+		case "url":
+			return []byte(URLEncodeFull(string(data))), nil
+		case "all":
+			return []byte(URLEncodeAll(string(data))), nil
+		}
+		return nil, fmt.Errorf("urlencode: unknown mode %q", mode)
+	})
+	// urldecode:n:form, n为解码次数(0表示解码到稳定), form将 + 视为空格
+	RegisterDSL("urldecode", func(data []byte, args ...string) ([]byte, error) {
+		n, form := 1, false
+		for _, arg := range args {
+			if arg == "form" {
+				form = true
+				continue
+			}
+			i, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, fmt.Errorf("urldecode: invalid option %q", arg)
+			}
+			n = i
+		}
+		return []byte(URLDecodeN(string(data), n, form)), nil
+	})
+}
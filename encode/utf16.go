@@ -0,0 +1,67 @@
+package encode
+
+import (
+	"fmt"
+	"unicode/utf16"
+)
+
+// UTF16Decode 将UTF-16转为UTF-8, 根据BOM判断字节序, 没有BOM时按小端处理
+func UTF16Decode(data []byte) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("odd utf-16 length %d", len(data))
+	}
+	bigEndian := false
+	switch {
+	case len(data) >= 2 && data[0] == 0xff && data[1] == 0xfe:
+		data = data[2:]
+	case len(data) >= 2 && data[0] == 0xfe && data[1] == 0xff:
+		data, bigEndian = data[2:], true
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i]) | uint16(data[2*i+1])<<8
+		}
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+// UTF16Encode 将UTF-8转为不带BOM的UTF-16LE, 即windows与powershell -enc使用的格式
+func UTF16Encode(data []byte) []byte {
+	return utf16LE(string(data))
+}
+
+// looksLikeUTF16 判断是否为UTF-16: 带BOM, 或者是偶数长度且奇数位(小端)或偶数位(大端)大部分为0的ASCII文本
+func looksLikeUTF16(data []byte) bool {
+	if len(data) < 2 || len(data)%2 != 0 {
+		return false
+	}
+	if data[0] == 0xff && data[1] == 0xfe || data[0] == 0xfe && data[1] == 0xff {
+		return true
+	}
+	if len(data) < 8 {
+		return false
+	}
+	var zeros int
+	for i := 1; i < len(data); i += 2 {
+		if data[i] == 0 && data[i-1] != 0 {
+			zeros++
+		}
+	}
+	return zeros*10 >= len(data)/2*9
+}
+
+func init() {
+	RegisterDSL("utf16de", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := UTF16Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("utf16de: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("utf16en", func(data []byte, args ...string) ([]byte, error) {
+		return UTF16Encode(data), nil
+	})
+}
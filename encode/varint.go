@@ -0,0 +1,150 @@
+package encode
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var (
+	ErrVarintTruncated = errors.New("varint: truncated input")
+	ErrVarintOverflow  = errors.New("varint: overflows 64-bit integer")
+	ErrFrameTooLarge   = errors.New("frame: size exceeds limit")
+)
+
+// EncodeUvarint 编码protobuf风格的无符号varint
+func EncodeUvarint(v uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, v)]
+}
+
+// DecodeUvarint 从b的开头解码无符号varint, 返回值与占用的字节数
+func DecodeUvarint(b []byte) (uint64, int, error) {
+	v, n := binary.Uvarint(b)
+	switch {
+	case n == 0:
+		return 0, 0, ErrVarintTruncated
+	case n < 0:
+		return 0, -n, ErrVarintOverflow
+	}
+	return v, n, nil
+}
+
+// EncodeVarint 编码zigzag形式的有符号varint, 即protobuf的sint64
+func EncodeVarint(v int64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutVarint(buf, v)]
+}
+
+// DecodeVarint 从b的开头解码zigzag形式的有符号varint, 返回值与占用的字节数
+func DecodeVarint(b []byte) (int64, int, error) {
+	v, n := binary.Varint(b)
+	switch {
+	case n == 0:
+		return 0, 0, ErrVarintTruncated
+	case n < 0:
+		return 0, -n, ErrVarintOverflow
+	}
+	return v, n, nil
+}
+
+// ReadUvarint 从r中读取一个无符号varint, r未实现io.ByteReader时逐字节读取, 不会多读
+func ReadUvarint(r io.Reader) (uint64, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &singleByteReader{r: r}
+	}
+	var v uint64
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b, err := br.ReadByte()
+		if err != nil {
+			if i > 0 && err == io.EOF {
+				return 0, ErrVarintTruncated
+			}
+			return 0, err
+		}
+		if i == binary.MaxVarintLen64-1 && b > 1 {
+			return 0, ErrVarintOverflow
+		}
+		v |= uint64(b&0x7f) << (7 * uint(i))
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+	return 0, ErrVarintOverflow
+}
+
+type singleByteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (br *singleByteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(br.r, br.buf[:]); err != nil {
+		return 0, err
+	}
+	return br.buf[0], nil
+}
+
+// WriteFrame 写入以uvarint长度为前缀的帧, 与protobuf的writeDelimited格式相同
+func WriteFrame(w io.Writer, data []byte) error {
+	if _, err := w.Write(EncodeUvarint(uint64(len(data)))); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// ReadFrame 读取 WriteFrame 写入的帧, maxSize限制帧的最大长度, 避免被恶意的长度字段耗尽内存, <=0时不限制
+// 流正常结束时返回io.EOF, 帧不完整时返回io.ErrUnexpectedEOF
+func ReadFrame(r io.Reader, maxSize int) ([]byte, error) {
+	size, err := ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	return readFrameBody(r, size, maxSize)
+}
+
+// WriteFrameUint32 写入以4字节定长长度为前缀的帧, order为nil时使用大端序
+func WriteFrameUint32(w io.Writer, data []byte, order binary.ByteOrder) error {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	if uint64(len(data)) > 0xffffffff {
+		return ErrFrameTooLarge
+	}
+	var head [4]byte
+	order.PutUint32(head[:], uint32(len(data)))
+	if _, err := w.Write(head[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// ReadFrameUint32 读取 WriteFrameUint32 写入的帧, order为nil时使用大端序
+func ReadFrameUint32(r io.Reader, order binary.ByteOrder, maxSize int) ([]byte, error) {
+	if order == nil {
+		order = binary.BigEndian
+	}
+	var head [4]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	return readFrameBody(r, uint64(order.Uint32(head[:])), maxSize)
+}
+
+func readFrameBody(r io.Reader, size uint64, maxSize int) ([]byte, error) {
+	if maxSize > 0 && size > uint64(maxSize) {
+		return nil, ErrFrameTooLarge
+	}
+	// 不按长度字段预先分配, 避免伪造的长度导致分配过大的内存
+	data, err := readAllPooled(io.LimitReader(r, int64(size&(1<<63-1))))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) != size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
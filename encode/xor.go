@@ -0,0 +1,79 @@
+package encode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// XorBytes 使用循环的多字节key对data做异或, key为空时返回data的副本
+func XorBytes(data, key []byte) []byte {
+	if len(key) == 0 {
+		return append([]byte(nil), data...)
+	}
+	return XorEncode(data, key, 0)
+}
+
+// ParseKey 解析key/iv等参数, 支持以下形式:
+//
+//	0x6b6579, hex:6b6579  hex编码
+//	b64:a2V5             base64编码
+//	str:key, ascii:key   原始字符串
+//	key                  不带前缀时总是视为原始字符串, 不会猜测hex, 例如 "cafe" 是4字节的key
+func ParseKey(s string) ([]byte, error) {
+	if i := strings.Index(s, ":"); i > 0 {
+		value := s[i+1:]
+		switch strings.ToLower(s[:i]) {
+		case "hex":
+			return hex.DecodeString(value)
+		case "b64", "base64":
+			return Base64DecodeE(value)
+		case "str", "ascii", "raw":
+			return []byte(value), nil
+		}
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return hex.DecodeString(s[2:])
+	}
+	return []byte(s), nil
+}
+
+// parseKeyArgs 将DSL参数还原为key, 如 xor:hex:6b 被切分为 ["hex", "6b"]
+func parseKeyArgs(args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("missing key")
+	}
+	return ParseKey(strings.Join(args, ":"))
+}
+
+// parseKeyList 将DSL参数还原为多个key, 如 aes-cbc:hex:00ff,str:iv 被切分为 ["hex", "00ff", "str", "iv"]
+func parseKeyList(args []string) ([][]byte, error) {
+	var keys [][]byte
+	for i := 0; i < len(args); i++ {
+		s := args[i]
+		switch strings.ToLower(s) {
+		case "hex", "b64", "base64", "str", "ascii", "raw":
+			if i+1 < len(args) {
+				i++
+				s += ":" + args[i]
+			}
+		}
+		key, err := ParseKey(s)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func init() {
+	// xor:0x6b, xor:hex:6b, xor:key, xor:b64:a2V5, 不带前缀的key按原始字符串处理, 格式见 ParseKey
+	RegisterDSL("xor", func(data []byte, args ...string) ([]byte, error) {
+		key, err := parseKeyArgs(args)
+		if err != nil {
+			return nil, fmt.Errorf("xor: %v", err)
+		}
+		return XorBytes(data, key), nil
+	})
+}
@@ -0,0 +1,181 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+
+	"github.com/ulikunitz/xz"
+	"github.com/ulikunitz/xz/lzma"
+)
+
+var xzMagic = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+
+// XzDecompress 解压xz数据
+func XzDecompress(input []byte) ([]byte, error) {
+	return XzDecompressLimited(input, 0)
+}
+
+// XzDecompressLimited 解压xz数据, 输出超过maxOut字节时返回 ErrDecompressLimit
+// 字典大小会被限制在maxOut以内, 伪造的块头无法使解压器预先分配过大的字典
+func XzDecompressLimited(input []byte, maxOut int64) ([]byte, error) {
+	var config xz.ReaderConfig
+	if maxOut > 0 {
+		// ReaderConfig.DictCap 是字典的下限, 实际大小取块头中声明的值, 因此同时需要改写块头
+		input = capXzDict(input, dictLimit(maxOut))
+		config.DictCap = lzma.MinDictCap
+	}
+	r, err := config.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	return readLimited(r, maxOut)
+}
+
+// LzmaDecompress 解压旧版.lzma(LZMA alone)格式的数据
+func LzmaDecompress(input []byte) ([]byte, error) {
+	return LzmaDecompressLimited(input, 0)
+}
+
+// LzmaDecompressLimited 解压.lzma格式的数据, 输出超过maxOut字节时返回 ErrDecompressLimit
+// 与 XzDecompressLimited 相同, 头部声明的字典大小会被限制在maxOut以内
+func LzmaDecompressLimited(input []byte, maxOut int64) ([]byte, error) {
+	var config lzma.ReaderConfig
+	if maxOut > 0 && len(input) >= lzma.HeaderLen {
+		limit := dictLimit(maxOut)
+		if int64(binary.LittleEndian.Uint32(input[1:5])) > limit {
+			input = append([]byte(nil), input...)
+			binary.LittleEndian.PutUint32(input[1:5], uint32(limit))
+		}
+		config.DictCap = int(limit)
+	}
+	r, err := config.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	return readLimited(r, maxOut)
+}
+
+// dictLimit 输出不超过maxOut时, 大于maxOut的字典没有意义
+func dictLimit(maxOut int64) int64 {
+	if maxOut < lzma.MinDictCap {
+		return lzma.MinDictCap
+	}
+	if maxOut > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return maxOut
+}
+
+// capXzDict 返回将各个块中LZMA2字典大小限制为limit的副本, 并重新计算块头的CRC32
+// 依次遍历块头, 遇到index或无法确定块大小时停止, 之后的块由xz包按原样处理
+func capXzDict(input []byte, limit int64) []byte {
+	if len(input) < 12 || !bytes.HasPrefix(input, xzMagic) {
+		return input
+	}
+	var checkLen int
+	switch input[7] & 0x0f {
+	case 0x00:
+		checkLen = 0
+	case 0x01:
+		checkLen = 4
+	case 0x04:
+		checkLen = 8
+	case 0x0a:
+		checkLen = 32
+	default:
+		return input
+	}
+	code := lzma2DictCode(limit)
+
+	out := append([]byte(nil), input...)
+	for pos := 12; pos < len(out) && out[pos] != 0; {
+		hlen := (int(out[pos]) + 1) * 4
+		if pos+hlen > len(out) {
+			break
+		}
+		hdr := out[pos : pos+hlen]
+		flags := hdr[1]
+		p := 2
+		readVarint := func() (uint64, bool) {
+			v, n := binary.Uvarint(hdr[p : hlen-4])
+			if n <= 0 {
+				return 0, false
+			}
+			p += n
+			return v, true
+		}
+
+		compressed, hasCompressed := uint64(0), flags&0x40 != 0
+		if hasCompressed {
+			var ok bool
+			if compressed, ok = readVarint(); !ok {
+				break
+			}
+		}
+		if flags&0x80 != 0 {
+			if _, ok := readVarint(); !ok {
+				break
+			}
+		}
+		valid := true
+		for i := 0; i < int(flags&0x03)+1 && valid; i++ {
+			id, ok1 := readVarint()
+			size, ok2 := readVarint()
+			if !ok1 || !ok2 || p+int(size) > hlen-4 {
+				valid = false
+				break
+			}
+			if id == 0x21 && size == 1 && hdr[p] > code {
+				hdr[p] = code
+			}
+			p += int(size)
+		}
+		if !valid {
+			break
+		}
+		binary.LittleEndian.PutUint32(hdr[hlen-4:], crc32.ChecksumIEEE(hdr[:hlen-4]))
+
+		if !hasCompressed || compressed > uint64(len(out)) {
+			break
+		}
+		pos += (hlen + int(compressed) + 3) &^ 3
+		pos += checkLen
+	}
+	return out
+}
+
+// lzma2DictCode 返回字典大小不小于size的最小LZMA2字典编码, 编码c对应 (2|c&1) << (c/2+11)
+func lzma2DictCode(size int64) byte {
+	for c := byte(0); c < 40; c++ {
+		if int64(2|c&1)<<(c/2+11) >= size {
+			return c
+		}
+	}
+	return 40
+}
+
+// NewXzReader 返回xz解压的reader
+func NewXzReader(r io.Reader) (io.Reader, error) {
+	return xz.NewReader(r)
+}
+
+func init() {
+	RegisterDSL("unxz", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := XzDecompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("unxz: %v", err)
+		}
+		return bs, nil
+	})
+	RegisterDSL("unlzma", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := LzmaDecompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("unlzma: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -0,0 +1,81 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"runtime"
+	"testing"
+
+	"github.com/ulikunitz/xz"
+	"github.com/ulikunitz/xz/lzma"
+)
+
+func xzCompress(t *testing.T, data []byte, blockSize int64) []byte {
+	var buf bytes.Buffer
+	w, err := xz.WriterConfig{BlockSize: blockSize}.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+// allocated 返回fn执行期间分配的字节数
+func allocated(fn func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func TestXzDecompressLimited(t *testing.T) {
+	plain := bytes.Repeat([]byte("whoami && echo hello\n"), 4096)
+	for _, blockSize := range []int64{0, 16 * 1024} {
+		compressed := xzCompress(t, plain, blockSize)
+		out, err := XzDecompressLimited(compressed, int64(len(plain)))
+		if err != nil || !bytes.Equal(out, plain) {
+			t.Fatalf("XzDecompressLimited(blockSize=%d) = %d bytes, %v", blockSize, len(out), err)
+		}
+		if _, err := XzDecompressLimited(compressed, int64(len(plain)-1)); err != ErrDecompressLimit {
+			t.Errorf("XzDecompressLimited over limit = %v; want ErrDecompressLimit", err)
+		}
+	}
+
+	// 将块头中的字典改为最大值(4GiB), 限制解压时不应按块头分配字典
+	bomb := xzCompress(t, []byte("admin"), 0)
+	hlen := (int(bomb[12]) + 1) * 4
+	hdr := bomb[12 : 12+hlen]
+	i := bytes.IndexByte(hdr[2:], 0x21) + 2
+	hdr[i+2] = 40
+	binary.LittleEndian.PutUint32(hdr[hlen-4:], crc32.ChecksumIEEE(hdr[:hlen-4]))
+	if n := allocated(func() { XzDecompressLimited(bomb, 1024) }); n > 32<<20 {
+		t.Errorf("XzDecompressLimited allocated %d bytes for a forged dictionary", n)
+	}
+}
+
+func TestLzmaDecompressLimited(t *testing.T) {
+	plain := bytes.Repeat([]byte("whoami && echo hello\n"), 4096)
+	var buf bytes.Buffer
+	w, _ := lzma.NewWriter(&buf)
+	w.Write(plain)
+	w.Close()
+	out, err := LzmaDecompressLimited(buf.Bytes(), int64(len(plain)))
+	if err != nil || !bytes.Equal(out, plain) {
+		t.Fatalf("LzmaDecompressLimited = %d bytes, %v", len(out), err)
+	}
+
+	// 22字节的头部声明了2GiB的字典与未知长度
+	bomb := append([]byte{0x5d, 0xff, 0xff, 0xff, 0x7f}, bytes.Repeat([]byte{0xff}, 8)...)
+	bomb = append(bomb, make([]byte, 9)...)
+	var decodeErr error
+	if n := allocated(func() { _, decodeErr = LzmaDecompressLimited(bomb, 1024) }); n > 32<<20 {
+		t.Errorf("LzmaDecompressLimited allocated %d bytes for a forged header", n)
+	}
+	if decodeErr == nil {
+		t.Errorf("LzmaDecompressLimited accepted a forged header")
+	}
+}
@@ -0,0 +1,75 @@
+package encode
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+)
+
+// ZlibCompress 压缩为带zlib头与adler32校验的deflate数据
+func ZlibCompress(input []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(input); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func ZlibDecompress(input []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readAllPooled(r)
+}
+
+// isZlibHeader 判断是否为zlib头: CM为8(deflate), 且 CMF<<8|FLG 能被31整除
+func isZlibHeader(data []byte) bool {
+	return len(data) >= 2 && data[0]&0x0f == 8 && data[0]>>4 <= 7 && (uint16(data[0])<<8|uint16(data[1]))%31 == 0
+}
+
+func init() {
+	RegisterDSL("zlib", func(data []byte, args ...string) ([]byte, error) {
+		return ZlibCompress(data)
+	})
+	RegisterDSL("unzlib", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := ZlibDecompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("unzlib: %v", err)
+		}
+		return bs, nil
+	})
+	// deflate:dict, 可选的预置字典, 格式见 ParseKey
+	RegisterDSL("deflate", func(data []byte, args ...string) ([]byte, error) {
+		if len(args) == 0 {
+			return DeflateCompressClosed(data)
+		}
+		dict, err := parseKeyArgs(args)
+		if err != nil {
+			return nil, fmt.Errorf("deflate: %v", err)
+		}
+		return DeflateCompressDict(data, dict)
+	})
+	// inflate:dict
+	RegisterDSL("inflate", func(data []byte, args ...string) ([]byte, error) {
+		var bs []byte
+		var err error
+		if len(args) == 0 {
+			bs, err = DeflateDeCompress(data)
+		} else {
+			var dict []byte
+			if dict, err = parseKeyArgs(args); err == nil {
+				bs, err = DeflateDeCompressDict(data, dict)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("inflate: %v", err)
+		}
+		return bs, nil
+	})
+}
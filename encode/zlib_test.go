@@ -0,0 +1,48 @@
+package encode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDeflateDict(t *testing.T) {
+	dict := []byte(`{"ip":"","port":,"protocol":"http","status":200,"title":""}`)
+	plain := []byte(`{"ip":"127.0.0.1","port":80,"protocol":"http","status":200,"title":"admin"}`)
+
+	compressed, err := DeflateCompressDict(plain, dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withoutDict, _ := DeflateCompressClosed(plain)
+	if len(compressed) >= len(withoutDict) {
+		t.Errorf("dict did not help: %d >= %d", len(compressed), len(withoutDict))
+	}
+	if bs, err := DeflateDeCompressDict(compressed, dict); err != nil || !bytes.Equal(bs, plain) {
+		t.Errorf("DeflateDeCompressDict = %q, %v", bs, err)
+	}
+
+	// raw deflate没有校验和, 错误的字典可能不报错但得到错误的数据
+	wrong := bytes.Repeat([]byte("x"), len(dict))
+	if bs, err := DeflateDeCompressDict(compressed, wrong); err == nil && bytes.Equal(bs, plain) {
+		t.Error("wrong dictionary decoded to the original data")
+	}
+	if bs, err := DeflateDeCompress(compressed); err == nil && bytes.Equal(bs, plain) {
+		t.Error("decoded without the dictionary")
+	}
+
+	// deflate:dict 与 inflate:dict, 字典格式见 ParseKey
+	out, err := DSLParserE("deflate:b64:" + Base64Encode(dict) + "|inflate:b64:" + Base64Encode(dict) + "|" + string(plain))
+	if err != nil || !bytes.Equal(out, plain) {
+		t.Errorf("deflate:dict|inflate:dict = %q, %v", out, err)
+	}
+	out, err = DSLParserE("deflate:str:admin|hex|admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DSLParserE("unhex|inflate|" + string(out)); err == nil {
+		t.Error("inflate without dictionary should fail")
+	}
+	if bs, err := DSLParserE("unhex|inflate:str:admin|" + string(out)); err != nil || string(bs) != "admin" {
+		t.Errorf("inflate:str:admin = %q, %v", bs, err)
+	}
+}
@@ -0,0 +1,76 @@
+package encode
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+// encoder与decoder的EncodeAll/DecodeAll可以并发调用, 全局复用避免重复分配窗口
+func initZstd() {
+	zstdOnce.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil)
+		zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+}
+
+func ZstdCompress(input []byte) ([]byte, error) {
+	initZstd()
+	return zstdEncoder.EncodeAll(input, nil), nil
+}
+
+func MustZstdCompress(input []byte) []byte {
+	output, err := ZstdCompress(input)
+	if err != nil {
+		panic(err)
+	}
+	return output
+}
+
+func ZstdDecompress(input []byte) ([]byte, error) {
+	initZstd()
+	return zstdDecoder.DecodeAll(input, nil)
+}
+
+func MustZstdDecompress(input []byte) []byte {
+	output, err := ZstdDecompress(input)
+	if err != nil {
+		panic(err)
+	}
+	return output
+}
+
+// NewZstdWriter 返回zstd压缩的writer, 必须调用Close写入结束帧
+func NewZstdWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+// NewZstdReader 返回zstd解压的reader
+func NewZstdReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+func init() {
+	RegisterDSL("zstd", func(data []byte, args ...string) ([]byte, error) {
+		return ZstdCompress(data)
+	})
+	RegisterDSL("unzstd", func(data []byte, args ...string) ([]byte, error) {
+		bs, err := ZstdDecompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("unzstd: %v", err)
+		}
+		return bs, nil
+	})
+}
@@ -1,8 +1,10 @@
 package fileutils
 
 import (
-	"io/ioutil"
+	"os"
 	"strings"
+
+	"github.com/chainreactors/utils/iutils"
 )
 
 var (
@@ -11,12 +13,20 @@ var (
 
 func LoadFileToSlice(filename string) ([]string, error) {
 	var ss []string
-	content, err := ioutil.ReadFile(filename)
+	f, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
+
+	// 字典文件会被反复加载, 读取时复用池化的buffer, 转为string时已复制, 归还后不影响结果
+	buf := iutils.GetBuffer()
+	defer iutils.PutBuffer(buf)
+	if _, err = buf.ReadFrom(f); err != nil {
+		return nil, err
+	}
 
-	ss = strings.Split(strings.TrimSpace(string(content)), "\n")
+	ss = strings.Split(strings.TrimSpace(buf.String()), "\n")
 
 	// 统一windows与linux的回车换行差异
 	for i, word := range ss {
@@ -60,3 +60,19 @@ func TestIP_Mask(t *testing.T) {
 	m := i.Mask(24)
 	println(i.String(), m.String())
 }
+
+func TestParseHostToIP(t *testing.T) {
+	// IP字面量不会发起DNS查询, 端口与URL部分在解析前被去除, Host保留原始输入
+	testCases := map[string]string{
+		"10.0.0.1":                      "10.0.0.1",
+		"192.168.1.1:80":                "192.168.1.1",
+		"http://192.168.1.1:8080/login": "192.168.1.1",
+		"https://[::1]:443/":            "::1",
+	}
+	for input, want := range testCases {
+		ip, err := ParseHostToIP(input)
+		if err != nil || ip.String() != want || ip.Host != input {
+			t.Errorf("ParseHostToIP(%q) = %v, %v; want %s", input, ip, err, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package iutils
+
+import (
+	"bytes"
+	"sync"
+)
+
+// 按容量分级的[]byte池, 超过最大级别的buffer不会被复用
+// 目前用于 fileutils 加载字典, CopyFile 的复制缓冲区以及encode中各解压/解码操作的读取
+var bufTiers = []int{512, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20}
+
+var (
+	bufPools    = make([]sync.Pool, len(bufTiers))
+	bufferPool  = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+	maxPoolSize = bufTiers[len(bufTiers)-1]
+)
+
+func init() {
+	for i := range bufPools {
+		size := bufTiers[i]
+		bufPools[i].New = func() interface{} {
+			buf := make([]byte, size)
+			return &buf
+		}
+	}
+}
+
+func bufTier(size int) int {
+	for i, tier := range bufTiers {
+		if size <= tier {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetBuf 获取长度为size的[]byte, 内容未清零, 使用完毕后通过PutBuf归还
+func GetBuf(size int) []byte {
+	i := bufTier(size)
+	if i == -1 {
+		return make([]byte, size)
+	}
+	buf := bufPools[i].Get().(*[]byte)
+	return (*buf)[:size]
+}
+
+// PutBuf 归还GetBuf获取的buffer, 归还后不能再使用
+func PutBuf(buf []byte) {
+	c := cap(buf)
+	i := bufTier(c)
+	if i == -1 || bufTiers[i] != c {
+		// 不是由GetBuf分配的buffer直接丢弃
+		return
+	}
+	buf = buf[:c]
+	bufPools[i].Put(&buf)
+}
+
+// GetBuffer 获取已清空的bytes.Buffer, 使用完毕后通过PutBuffer归还
+func GetBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// PutBuffer 归还bytes.Buffer, 过大的buffer会被丢弃以免长期占用内存
+func PutBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPoolSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
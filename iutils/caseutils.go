@@ -0,0 +1,85 @@
+package iutils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitWords 按分隔符与大小写变化拆分单词, 连续大写视为一个缩写, 例如 "HTTPServer_id" -> ["HTTP", "Server", "id"]
+func SplitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start != -1 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start == -1 {
+			start = i
+			continue
+		}
+
+		prev := runes[i-1]
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) ||
+			unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start != -1 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// ToSnake "HTTPServerID" -> "http_server_id"
+func ToSnake(s string) string {
+	return joinLowerWords(s, "_")
+}
+
+// ToKebab "HTTPServerID" -> "http-server-id"
+func ToKebab(s string) string {
+	return joinLowerWords(s, "-")
+}
+
+// ToCamel "http_server_id" -> "HttpServerId"
+func ToCamel(s string) string {
+	var b strings.Builder
+	for _, word := range SplitWords(s) {
+		b.WriteString(capitalize(word))
+	}
+	return b.String()
+}
+
+// ToLowerCamel "http_server_id" -> "httpServerId"
+func ToLowerCamel(s string) string {
+	var b strings.Builder
+	for i, word := range SplitWords(s) {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word))
+		} else {
+			b.WriteString(capitalize(word))
+		}
+	}
+	return b.String()
+}
+
+func joinLowerWords(s, sep string) string {
+	words := SplitWords(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, sep)
+}
+
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
@@ -0,0 +1,165 @@
+package iutils
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/transform"
+)
+
+var charsets = map[string]encoding.Encoding{
+	"gbk":          simplifiedchinese.GBK,
+	"gb2312":       simplifiedchinese.GBK, // GBK 是 GB2312 的超集
+	"gb18030":      simplifiedchinese.GB18030,
+	"big5":         traditionalchinese.Big5,
+	"latin1":       charmap.ISO8859_1,
+	"iso-8859-1":   charmap.ISO8859_1,
+	"windows-1252": charmap.Windows1252,
+}
+
+// GetCharset 根据名称获取编码, 名称不区分大小写, 支持 gbk, gb2312, gb18030, big5, latin1 等
+func GetCharset(name string) (encoding.Encoding, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if enc, ok := charsets[name]; ok {
+		return enc, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %s", name)
+}
+
+// DecodeCharset 将指定编码的数据转为UTF-8
+func DecodeCharset(src []byte, charset string) ([]byte, error) {
+	if isUTF8Charset(charset) {
+		return src, nil
+	}
+	enc, err := GetCharset(charset)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(transform.NewReader(bytes.NewReader(src), enc.NewDecoder()))
+}
+
+// EncodeCharset 将UTF-8数据转为指定编码
+func EncodeCharset(src []byte, charset string) ([]byte, error) {
+	if isUTF8Charset(charset) {
+		return src, nil
+	}
+	enc, err := GetCharset(charset)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(transform.NewReader(bytes.NewReader(src), enc.NewEncoder()))
+}
+
+func GBKToUTF8(src []byte) ([]byte, error) {
+	return DecodeCharset(src, "gbk")
+}
+
+func UTF8ToGBK(src []byte) ([]byte, error) {
+	return EncodeCharset(src, "gbk")
+}
+
+func Big5ToUTF8(src []byte) ([]byte, error) {
+	return DecodeCharset(src, "big5")
+}
+
+// DetectCharset 在UTF-8, GBK与Big5之间猜测数据的编码, 均不符合时返回空字符串
+func DetectCharset(src []byte) string {
+	if utf8.Valid(src) {
+		return "utf-8"
+	}
+	gbk, big5 := validDBCS(src, isGBKLead, isGBKTrail), validDBCS(src, isBig5Lead, isBig5Trail)
+	if gbk && big5 {
+		// Big5的字节范围几乎是GBK的子集, 两者都合法时按次字节区分:
+		// GB2312中的字符次字节均>=0xA1, 而Big5约四成汉字的次字节位于0x40-0x7E
+		if lowTrailRatio(src) >= 0.1 {
+			return "big5"
+		}
+		return "gbk"
+	}
+	if gbk {
+		return "gbk"
+	}
+	if big5 {
+		return "big5"
+	}
+	return ""
+}
+
+// ToUTF8 自动识别编码并转为UTF-8, 无法识别时退化为 UTF8ConvertBytes
+func ToUTF8(src []byte) []byte {
+	charset := DetectCharset(src)
+	if charset == "" {
+		return UTF8ConvertBytes(src)
+	}
+	dst, err := DecodeCharset(src, charset)
+	if err != nil {
+		return UTF8ConvertBytes(src)
+	}
+	return dst
+}
+
+func ToUTF8String(src string) string {
+	return string(ToUTF8([]byte(src)))
+}
+
+func isUTF8Charset(charset string) bool {
+	charset = strings.ToLower(strings.TrimSpace(charset))
+	return charset == "utf-8" || charset == "utf8"
+}
+
+// 双字节编码的合法性检查, 首字节与次字节分别由lead与trail判断
+func validDBCS(src []byte, lead, trail func(byte) bool) bool {
+	for i := 0; i < len(src); i++ {
+		b := src[i]
+		if b < 0x80 {
+			continue
+		}
+		if !lead(b) || i+1 >= len(src) || !trail(src[i+1]) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// lowTrailRatio 双字节字符中次字节位于0x40-0x7E的比例, 调用前需保证src是合法的双字节编码
+func lowTrailRatio(src []byte) float64 {
+	var total, low int
+	for i := 0; i+1 < len(src); i++ {
+		if src[i] < 0x80 {
+			continue
+		}
+		total++
+		if src[i+1] <= 0x7e {
+			low++
+		}
+		i++
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(low) / float64(total)
+}
+
+func isGBKLead(b byte) bool {
+	return b >= 0x81 && b <= 0xfe
+}
+
+func isBig5Lead(b byte) bool {
+	return b >= 0xa1 && b <= 0xf9
+}
+
+func isGBKTrail(b byte) bool {
+	return b >= 0x40 && b <= 0xfe && b != 0x7f
+}
+
+func isBig5Trail(b byte) bool {
+	return (b >= 0x40 && b <= 0x7e) || (b >= 0xa1 && b <= 0xfe)
+}
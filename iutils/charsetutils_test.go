@@ -0,0 +1,34 @@
+package iutils
+
+import "testing"
+
+func TestDetectCharset(t *testing.T) {
+	cases := []struct {
+		text    string
+		charset string
+	}{
+		{"这是一段简体中文测试, 用于检测编码", "gbk"},
+		{"扫描结果", "gbk"},
+		{"這是一段繁體中文測試, 用於檢測編碼", "big5"},
+		{"繁體中文", "big5"},
+	}
+	for _, c := range cases {
+		src, err := EncodeCharset([]byte(c.text), c.charset)
+		if err != nil {
+			t.Fatalf("EncodeCharset(%q, %s): %v", c.text, c.charset, err)
+		}
+		if got := DetectCharset(src); got != c.charset {
+			t.Errorf("DetectCharset(%q as %s) = %q", c.text, c.charset, got)
+		}
+		if got := string(ToUTF8(src)); got != c.text {
+			t.Errorf("ToUTF8(%q as %s) = %q", c.text, c.charset, got)
+		}
+	}
+
+	if got := DetectCharset([]byte("ascii only")); got != "utf-8" {
+		t.Errorf("DetectCharset(ascii) = %q; want utf-8", got)
+	}
+	if got := DetectCharset([]byte{0xff, 0xfe, 0x80}); got != "" {
+		t.Errorf("DetectCharset(invalid) = %q; want empty", got)
+	}
+}
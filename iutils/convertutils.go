@@ -0,0 +1,182 @@
+package iutils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ToIntE 将任意类型转为int, 无法转换时返回error
+func ToIntE(i interface{}) (int, error) {
+	v, err := ToInt64E(i)
+	if err != nil {
+		return 0, err
+	}
+	if int64(int(v)) != v {
+		return 0, fmt.Errorf("%d overflows int", v)
+	}
+	return int(v), nil
+}
+
+// ToInt64E 将任意类型转为int64, 无法转换时返回error
+func ToInt64E(i interface{}) (int64, error) {
+	switch s := i.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return int64(s), nil
+	case int64:
+		return s, nil
+	case int32:
+		return int64(s), nil
+	case int16:
+		return int64(s), nil
+	case int8:
+		return int64(s), nil
+	case uint:
+		if uint64(s) > 1<<63-1 {
+			return 0, fmt.Errorf("%d overflows int64", s)
+		}
+		return int64(s), nil
+	case uint64:
+		if s > 1<<63-1 {
+			return 0, fmt.Errorf("%d overflows int64", s)
+		}
+		return int64(s), nil
+	case uint32:
+		return int64(s), nil
+	case uint16:
+		return int64(s), nil
+	case uint8:
+		return int64(s), nil
+	case float64:
+		return floatToInt64(s)
+	case float32:
+		return floatToInt64(float64(s))
+	case bool:
+		if s {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return parseInt64(s)
+	case []byte:
+		return parseInt64(string(s))
+	default:
+		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", i, i)
+	}
+}
+
+// ToUintE 将任意类型转为uint, 负数与无法转换时返回error
+func ToUintE(i interface{}) (uint, error) {
+	switch s := i.(type) {
+	case uint:
+		return s, nil
+	case uint64:
+		if uint64(uint(s)) != s {
+			return 0, fmt.Errorf("%d overflows uint", s)
+		}
+		return uint(s), nil
+	}
+
+	v, err := ToInt64E(i)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("unable to cast negative value %d to uint", v)
+	}
+	return uint(v), nil
+}
+
+// ToFloat64E 将任意类型转为float64, 无法转换时返回error
+func ToFloat64E(i interface{}) (float64, error) {
+	switch s := i.(type) {
+	case float64:
+		return s, nil
+	case float32:
+		return float64(s), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	case []byte:
+		return strconv.ParseFloat(strings.TrimSpace(string(s)), 64)
+	}
+
+	v, err := ToInt64E(i)
+	if err != nil {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", i, i)
+	}
+	return float64(v), nil
+}
+
+// ToBoolE 将任意类型转为bool, 字符串支持 1/0, true/false, yes/no, on/off
+func ToBoolE(i interface{}) (bool, error) {
+	switch s := i.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return s, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "1", "t", "true", "y", "yes", "on":
+			return true, nil
+		case "0", "f", "false", "n", "no", "off", "":
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to cast %q to bool", s)
+	case []byte:
+		return ToBoolE(string(s))
+	}
+
+	v, err := ToInt64E(i)
+	if err != nil {
+		return false, fmt.Errorf("unable to cast %#v of type %T to bool", i, i)
+	}
+	return v != 0, nil
+}
+
+func ToInt64(i interface{}) int64 {
+	v, _ := ToInt64E(i)
+	return v
+}
+
+func ToUint(i interface{}) uint {
+	v, _ := ToUintE(i)
+	return v
+}
+
+func ToFloat64(i interface{}) float64 {
+	v, _ := ToFloat64E(i)
+	return v
+}
+
+func ToBool(i interface{}) bool {
+	v, _ := ToBoolE(i)
+	return v
+}
+
+// 默认按十进制解析, 同时支持 0x/0o/0b 前缀, 以及形如 "1.0" 的整数值浮点字符串
+func parseInt64(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v, nil
+	}
+	if v, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to cast %q to int64", s)
+	}
+	return floatToInt64(f)
+}
+
+// floatToInt64 仅接受整数值且在int64范围内的浮点数, 不做截断
+func floatToInt64(f float64) (int64, error) {
+	// -2^63可以精确表示, 2^63已超出int64范围, NaN在比较中恒为false
+	if !(f >= -(1<<63) && f < 1<<63) || f != math.Trunc(f) {
+		return 0, fmt.Errorf("unable to cast %v to int64 without loss", f)
+	}
+	return int64(f), nil
+}
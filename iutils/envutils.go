@@ -0,0 +1,32 @@
+package iutils
+
+import (
+	"os"
+	"strings"
+)
+
+// ExpandEnv 与os.ExpandEnv类似, 额外支持shell风格的默认值
+// ${VAR:-default} 在VAR未设置或为空时使用default, ${VAR-default} 仅在VAR未设置时使用default
+func ExpandEnv(s string) string {
+	return ExpandWith(s, os.LookupEnv)
+}
+
+// ExpandWith 使用自定义的lookup展开变量, 可用于配置文件与DSL payload中的变量替换
+func ExpandWith(s string, lookup func(string) (string, bool)) string {
+	return os.Expand(s, func(expr string) string {
+		if i := strings.Index(expr, ":-"); i != -1 {
+			if v, ok := lookup(expr[:i]); ok && v != "" {
+				return v
+			}
+			return expr[i+2:]
+		}
+		if i := strings.Index(expr, "-"); i != -1 {
+			if v, ok := lookup(expr[:i]); ok {
+				return v
+			}
+			return expr[i+1:]
+		}
+		v, _ := lookup(expr)
+		return v
+	})
+}
@@ -0,0 +1,58 @@
+package iutils
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// CommandOptions RunCommandWithOptions 的可选参数
+type CommandOptions struct {
+	Timeout time.Duration // 为0时不设置超时, 仍受ctx控制
+	Env     []string      // "KEY=VALUE" 形式, 追加在当前进程的环境变量之后
+	Dir     string        // 工作目录, 为空时使用当前目录
+	Stdin   io.Reader
+}
+
+// RunCommand 执行命令并分别捕获stdout与stderr
+// 命令无法启动时code为-1, 非0退出时err为*exec.ExitError
+func RunCommand(ctx context.Context, name string, args ...string) (stdout, stderr []byte, code int, err error) {
+	return RunCommandWithOptions(ctx, nil, name, args...)
+}
+
+func RunCommandWithOptions(ctx context.Context, opts *CommandOptions, name string, args ...string) (stdout, stderr []byte, code int, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts == nil {
+		opts = &CommandOptions{}
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	cmd.Stdin = opts.Stdin
+	cmd.Dir = opts.Dir
+	if len(opts.Env) > 0 {
+		cmd.Env = append(os.Environ(), opts.Env...)
+	}
+
+	err = cmd.Run()
+	code = -1
+	if cmd.ProcessState != nil {
+		code = cmd.ProcessState.ExitCode()
+	}
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return outBuf.Bytes(), errBuf.Bytes(), code, err
+}
@@ -0,0 +1,164 @@
+package iutils
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// FileExists 判断文件或目录是否存在
+func FileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func IsDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func IsFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// IsWritable 判断路径是否可写, 目录通过创建临时文件检测, 不存在的文件检查其所在目录
+func IsWritable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return IsWritable(filepath.Dir(path))
+		}
+		return false
+	}
+
+	if info.IsDir() {
+		f, err := ioutil.TempFile(path, ".write_test")
+		if err != nil {
+			return false
+		}
+		name := f.Name()
+		f.Close()
+		os.Remove(name)
+		return true
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// EnsureDir 目录不存在时递归创建, 路径已存在但不是目录时返回错误
+func EnsureDir(path string, perm os.FileMode) error {
+	info, err := os.Stat(path)
+	if err == nil {
+		if !info.IsDir() {
+			return &os.PathError{Op: "mkdir", Path: path, Err: os.ErrExist}
+		}
+		return nil
+	}
+	return os.MkdirAll(path, perm)
+}
+
+// FirstExisting 返回第一个存在的路径, 路径会经过ExpandPath展开, 均不存在时返回空字符串
+// 常用于在多个候选位置中查找数据文件
+func FirstExisting(paths ...string) string {
+	for _, path := range paths {
+		path = ExpandPath(path)
+		if FileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// CopyOptions CopyFileWithOptions与CopyDir的可选参数
+type CopyOptions struct {
+	// Chtime 为true时复制后使用Chtime统一修改时间戳, 否则保留源文件的修改时间
+	Chtime bool
+	// Filter 返回false时跳过该文件, 跳过目录时不再遍历其子目录
+	Filter func(path string, info os.FileInfo) bool
+}
+
+// CopyFile 复制文件, 保留权限与修改时间
+func CopyFile(src, dst string) error {
+	return CopyFileWithOptions(src, dst, nil)
+}
+
+func CopyFileWithOptions(src, dst string, opts *CopyOptions) error {
+	if opts == nil {
+		opts = &CopyOptions{}
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	buf := GetBuf(32 << 10)
+	_, err = io.CopyBuffer(out, in, buf)
+	PutBuf(buf)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	// 目标文件已存在时OpenFile不会修改权限
+	if err = os.Chmod(dst, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	if opts.Chtime {
+		Chtime(dst)
+		return nil
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+// CopyDir 递归复制目录, 可以通过opts.Filter过滤文件
+func CopyDir(src, dst string, opts *CopyOptions) error {
+	if opts == nil {
+		opts = &CopyOptions{}
+	}
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != src && opts.Filter != nil && !opts.Filter(path, info) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case info.IsDir():
+			return EnsureDir(target, info.Mode().Perm())
+		case info.Mode().IsRegular():
+			return CopyFileWithOptions(path, target, opts)
+		default:
+			// 跳过符号链接, 设备文件等
+			return nil
+		}
+	})
+}
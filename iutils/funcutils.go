@@ -0,0 +1,50 @@
+package iutils
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce 返回包装后的函数, 连续调用时只在最后一次调用的d之后执行一次fn, 适用于配置重载等场景
+func Debounce(fn func(), d time.Duration) func() {
+	var mu sync.Mutex
+	var timer *time.Timer
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(d, fn)
+	}
+}
+
+// Throttle 返回包装后的函数, 每个d周期内最多执行一次fn, 适用于刷新进度等场景
+// 周期内被忽略的调用会在周期结束时补执行一次, 保证最后的状态不会丢失
+func Throttle(fn func(), d time.Duration) func() {
+	var mu sync.Mutex
+	var last time.Time
+	var pending bool
+	return func() {
+		mu.Lock()
+		if pending {
+			mu.Unlock()
+			return
+		}
+		if wait := d - time.Since(last); wait > 0 {
+			pending = true
+			time.AfterFunc(wait, func() {
+				mu.Lock()
+				pending = false
+				last = time.Now()
+				mu.Unlock()
+				fn()
+			})
+			mu.Unlock()
+			return
+		}
+		last = time.Now()
+		mu.Unlock()
+		fn()
+	}
+}
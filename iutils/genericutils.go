@@ -0,0 +1,71 @@
+package iutils
+
+// UniqueOf 切片去重, 保持元素第一次出现的顺序
+func UniqueOf[T comparable](s []T) []T {
+	res := make([]T, 0, len(s))
+	seen := make(map[T]struct{}, len(s))
+	for _, item := range s {
+		if _, ok := seen[item]; !ok {
+			seen[item] = struct{}{}
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// ChunkOf 将切片按size切分为多个子切片, 最后一个子切片可能不足size, size<=0时不切分
+func ChunkOf[T any](s []T, size int) [][]T {
+	if size <= 0 {
+		return [][]T{s}
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for size < len(s) {
+		s, chunks = s[size:], append(chunks, s[:size:size])
+	}
+	if len(s) > 0 {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// IntersectOf 返回同时存在于a与b中的元素, 保持a中的顺序并去重
+func IntersectOf[T comparable](a, b []T) []T {
+	return filterByExist(a, b, true)
+}
+
+// DiffOf 返回存在于a但不存在于b中的元素, 保持a中的顺序并去重
+func DiffOf[T comparable](a, b []T) []T {
+	return filterByExist(a, b, false)
+}
+
+func filterByExist[T comparable](a, b []T, exist bool) []T {
+	set := make(map[T]struct{}, len(b))
+	for _, item := range b {
+		set[item] = struct{}{}
+	}
+	res := make([]T, 0)
+	for _, item := range UniqueOf(a) {
+		if _, ok := set[item]; ok == exist {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func FilterOf[T any](s []T, fn func(T) bool) []T {
+	res := make([]T, 0, len(s))
+	for _, item := range s {
+		if fn(item) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func MapOf[T, R any](s []T, fn func(T) R) []R {
+	res := make([]R, len(s))
+	for i, item := range s {
+		res[i] = fn(item)
+	}
+	return res
+}
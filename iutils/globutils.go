@@ -0,0 +1,127 @@
+package iutils
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Glob 在filepath.Glob的基础上支持 ** 匹配任意层目录, 以及 {a,b} 形式的花括号展开
+// 例如 "dicts/**/*.{txt,dic}", 返回排序去重后的路径
+func Glob(pattern string) ([]string, error) {
+	var matches []string
+	for _, p := range ExpandBraces(ExpandPath(pattern)) {
+		var ms []string
+		var err error
+		if strings.Contains(p, "**") {
+			ms, err = globStar(p)
+		} else {
+			ms, err = filepath.Glob(p)
+		}
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, ms...)
+	}
+	matches = StringsUnique(matches)
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// ExpandBraces 展开花括号, 支持嵌套, 例如 "a{b,c{d,e}}" -> ["ab", "acd", "ace"]
+func ExpandBraces(pattern string) []string {
+	start := strings.Index(pattern, "{")
+	if start == -1 {
+		return []string{pattern}
+	}
+
+	var depth, end int
+	var options []string
+	last := start + 1
+	for i := start; i < len(pattern) && end == 0; i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				options = append(options, pattern[last:i])
+				end = i
+			}
+		case ',':
+			if depth == 1 {
+				options = append(options, pattern[last:i])
+				last = i + 1
+			}
+		}
+	}
+	if end == 0 {
+		// 花括号未闭合, 按字面量处理
+		return []string{pattern}
+	}
+
+	var res []string
+	for _, option := range options {
+		res = append(res, ExpandBraces(pattern[:start]+option+pattern[end+1:])...)
+	}
+	return res
+}
+
+func globStar(pattern string) ([]string, error) {
+	parts := strings.Split(filepath.ToSlash(pattern), "/")
+
+	// 不含通配符的前缀作为遍历的根目录
+	var i int
+	for i < len(parts)-1 && !strings.ContainsAny(parts[i], "*?[") {
+		i++
+	}
+	root := strings.Join(parts[:i], "/")
+	if root == "" {
+		if strings.HasPrefix(pattern, "/") {
+			root = "/"
+		} else {
+			root = "."
+		}
+	}
+	patternParts := parts[i:]
+
+	var matches []string
+	err := filepath.Walk(filepath.FromSlash(root), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// 跳过无权限访问的目录
+			return nil
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(root), path)
+		if err != nil || rel == "." {
+			return nil
+		}
+		if matchParts(patternParts, strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// matchParts 逐段匹配路径, ** 可以匹配零或多段
+func matchParts(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchParts(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if ok, err := filepath.Match(pattern[0], path[0]); err != nil || !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
@@ -0,0 +1,53 @@
+package iutils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.dic", "c.log", "sub/d.txt", "sub/deep/e.dic", "sub/deep/f.log"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		pattern string
+		want    []string
+	}{
+		{"*.txt", []string{"a.txt"}},
+		{"*.{txt,dic}", []string{"a.txt", "b.dic"}},
+		{"**/*.txt", []string{"a.txt", "sub/d.txt"}},
+		{"**/*.{txt,dic}", []string{"a.txt", "b.dic", "sub/d.txt", "sub/deep/e.dic"}},
+		{"sub/**/*.log", []string{"sub/deep/f.log"}},
+		{"{a,a}.txt", []string{"a.txt"}},
+		{"*.none", nil},
+	}
+	for _, c := range cases {
+		got, err := Glob(filepath.Join(dir, c.pattern))
+		if err != nil {
+			t.Errorf("Glob(%q): %v", c.pattern, err)
+			continue
+		}
+		var rel []string
+		for _, path := range got {
+			r, _ := filepath.Rel(dir, path)
+			rel = append(rel, filepath.ToSlash(r))
+		}
+		if !reflect.DeepEqual(rel, c.want) {
+			t.Errorf("Glob(%q) = %v; want %v", c.pattern, rel, c.want)
+		}
+	}
+
+	if _, err := Glob(filepath.Join(dir, "[")); err == nil {
+		t.Errorf("Glob with malformed pattern should fail")
+	}
+}
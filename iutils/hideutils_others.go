@@ -0,0 +1,37 @@
+//go:build !windows
+// +build !windows
+
+package iutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HideFile 隐藏文件, unix下通过添加 . 前缀重命名实现, 返回隐藏后的路径, 目标已存在时返回error
+func HideFile(path string) (string, error) {
+	dir, name := filepath.Split(path)
+	if strings.HasPrefix(name, ".") {
+		return path, nil
+	}
+	hidden := filepath.Join(dir, "."+name)
+	// os.Rename 会直接覆盖已存在的目标, 需要提前检查
+	if _, err := os.Lstat(hidden); err == nil {
+		return path, &os.PathError{Op: "hide", Path: hidden, Err: os.ErrExist}
+	} else if !os.IsNotExist(err) {
+		return path, err
+	}
+	if err := os.Rename(path, hidden); err != nil {
+		return path, err
+	}
+	return hidden, nil
+}
+
+// IsHidden 判断文件是否为隐藏文件, unix下以 . 开头即为隐藏
+func IsHidden(path string) (bool, error) {
+	if _, err := os.Lstat(path); err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(filepath.Base(path), "."), nil
+}
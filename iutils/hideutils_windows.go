@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+package iutils
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// HideFile 为文件设置 FILE_ATTRIBUTE_HIDDEN 属性, 路径不变
+func HideFile(path string) (string, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return path, err
+	}
+	attrs, err := windows.GetFileAttributes(p)
+	if err != nil {
+		return path, err
+	}
+	return path, windows.SetFileAttributes(p, attrs|windows.FILE_ATTRIBUTE_HIDDEN)
+}
+
+// IsHidden 判断文件是否带有 FILE_ATTRIBUTE_HIDDEN 属性
+func IsHidden(path string) (bool, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return false, err
+	}
+	attrs, err := windows.GetFileAttributes(p)
+	if err != nil {
+		return false, err
+	}
+	return attrs&windows.FILE_ATTRIBUTE_HIDDEN != 0, nil
+}
@@ -0,0 +1,44 @@
+package iutils
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NormalizeHost 规范化主机名, 用于去重与DNS解析之前
+// 将全角字符转为半角, 统一小写, 去除结尾的点, 并校验总长度(253)与每个label的长度(63)
+// IP地址会被规范化为标准形式
+func NormalizeHost(s string) (string, error) {
+	s = strings.TrimSpace(FoldWidth(s))
+	s = strings.TrimRight(strings.ToLower(s), ".")
+
+	if ip := net.ParseIP(strings.Trim(s, "[]")); ip != nil {
+		return ip.String(), nil
+	}
+
+	if s == "" {
+		return "", fmt.Errorf("empty host")
+	}
+	if len(s) > 253 {
+		return "", fmt.Errorf("host %q exceeds 253 bytes", s)
+	}
+	for _, label := range strings.Split(s, ".") {
+		if len(label) == 0 {
+			return "", fmt.Errorf("host %q contains empty label", s)
+		}
+		if len(label) > 63 {
+			return "", fmt.Errorf("label %q of host %q exceeds 63 bytes", label, s)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return "", fmt.Errorf("label %q of host %q starts or ends with hyphen", label, s)
+		}
+		for _, r := range label {
+			// 非ASCII字符视为国际化域名, 交由punycode处理
+			if r < 0x80 && !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return "", fmt.Errorf("host %q contains invalid character %q", s, r)
+			}
+		}
+	}
+	return s, nil
+}
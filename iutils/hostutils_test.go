@@ -0,0 +1,38 @@
+package iutils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeHost(t *testing.T) {
+	cases := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"Example.COM", "example.com", true},
+		{" example.com. ", "example.com", true},
+		{"ｅｘａｍｐｌｅ．ｃｏｍ", "example.com", true},
+		{"_dmarc.example.com", "_dmarc.example.com", true},
+		{"localhost", "localhost", true},
+		{"例子.测试", "例子.测试", true},
+		{"192.168.001.1", "192.168.001.1", true},
+		{"10.0.0.1", "10.0.0.1", true},
+		{"[::1]", "::1", true},
+		{"2001:DB8::1", "2001:db8::1", true},
+		{"", "", false},
+		{"a..com", "", false},
+		{"-a.com", "", false},
+		{"a-.com", "", false},
+		{"a b.com", "", false},
+		{"a/b.com", "", false},
+		{strings.Repeat("a", 64) + ".com", "", false},
+		{strings.Repeat("a.", 127) + "com", "", false},
+	}
+	for _, c := range cases {
+		got, err := NormalizeHost(c.in)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("NormalizeHost(%q) = %q, %v; want %q, ok=%v", c.in, got, err, c.want, c.ok)
+		}
+	}
+}
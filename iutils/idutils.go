@@ -0,0 +1,32 @@
+package iutils
+
+import (
+	"fmt"
+	"time"
+)
+
+const base62Chars = Digits + UpperLetters + LowerLetters
+
+// UUID 生成随机的UUIDv4, 例如 "f47ac10b-58cc-4372-a567-0e02b2c3d479", 系统随机源不可用时panic
+func UUID() string {
+	b := Must(RandomBytes(16))
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant RFC4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// ShortID 生成16位的base62 ID, 用于标记扫描会话与关联不同输出文件中的结果
+// 前7位为毫秒时间戳, 因此按字典序排序即按生成时间排序, 后9位为随机字符(约53位熵), 系统随机源不可用时panic
+func ShortID() string {
+	return base62Time(time.Now()) + Must(RandomString(9, base62Chars))
+}
+
+func base62Time(t time.Time) string {
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	buf := make([]byte, 7)
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = base62Chars[ms%62]
+		ms /= 62
+	}
+	return string(buf)
+}
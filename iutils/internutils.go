@@ -0,0 +1,73 @@
+package iutils
+
+import (
+	"sync"
+)
+
+// Interner 字符串驻留池, 相同内容的字符串共享同一份内存
+// 适用于服务名, 状态行, 端口等高度重复的字符串, 避免保存大量结果时重复占用内存
+type Interner struct {
+	mu      sync.RWMutex
+	strings map[string]string
+	max     int
+}
+
+// NewInterner max为最多驻留的字符串数量, 达到上限后不再驻留新的字符串, max<=0时不限制
+func NewInterner(max int) *Interner {
+	return &Interner{strings: make(map[string]string), max: max}
+}
+
+var defaultInterner = NewInterner(1 << 20)
+
+// Intern 使用默认的驻留池
+func Intern(s string) string {
+	return defaultInterner.Intern(s)
+}
+
+func InternBytes(b []byte) string {
+	return defaultInterner.InternBytes(b)
+}
+
+func (in *Interner) Intern(s string) string {
+	in.mu.RLock()
+	interned, ok := in.strings[s]
+	in.mu.RUnlock()
+	if ok {
+		return interned
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if interned, ok = in.strings[s]; ok {
+		return interned
+	}
+	if in.max > 0 && len(in.strings) >= in.max {
+		return s
+	}
+	in.strings[s] = s
+	return s
+}
+
+// InternBytes 命中时不会产生额外的内存分配
+func (in *Interner) InternBytes(b []byte) string {
+	in.mu.RLock()
+	interned, ok := in.strings[string(b)]
+	in.mu.RUnlock()
+	if ok {
+		return interned
+	}
+	return in.Intern(string(b))
+}
+
+func (in *Interner) Len() int {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return len(in.strings)
+}
+
+// Reset 清空驻留池, 已返回的字符串不受影响
+func (in *Interner) Reset() {
+	in.mu.Lock()
+	in.strings = make(map[string]string)
+	in.mu.Unlock()
+}
@@ -0,0 +1,96 @@
+package iutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseKV 解析 "a=1,b=2" 形式的字符串, sep为键值对之间的分隔符, kvSep为键与值的分隔符
+// 值可以使用单引号或双引号包裹以包含分隔符, 例如 `header="a=1,b=2",ua='x y'`, 双引号中支持反斜杠转义
+// 没有kvSep的项视为值为空字符串的key
+func ParseKV(s, sep, kvSep string) (map[string]string, error) {
+	if sep == "" {
+		sep = ","
+	}
+	if kvSep == "" {
+		kvSep = "="
+	}
+
+	pairs, err := splitQuoted(s, sep, kvSep)
+	if err != nil {
+		return nil, err
+	}
+	kv := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		parts, err := splitQuoted(pair, kvSep, kvSep)
+		if err != nil {
+			return nil, err
+		}
+		key := unquoteKV(parts[0])
+		if key == "" {
+			return nil, fmt.Errorf("empty key in %q", pair)
+		}
+		var value string
+		if len(parts) > 1 {
+			// 只在第一个kvSep处拆分, 值中的kvSep保留
+			value = unquoteKV(strings.Join(parts[1:], kvSep))
+		}
+		kv[key] = value
+	}
+	return kv, nil
+}
+
+// splitQuoted 按sep拆分字符串, 忽略引号内的sep, 保留引号以便后续处理
+// 只有位于项开头或紧跟kvSep的引号才被视为引号, 因此 it's 这样的值不受影响
+func splitQuoted(s, sep, kvSep string) ([]string, error) {
+	var parts []string
+	var quote byte
+	last := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if prefix := strings.TrimSpace(s[last:i]); prefix == "" || strings.HasSuffix(prefix, kvSep) {
+				quote = c
+			}
+		case strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[last:i])
+			i += len(sep) - 1
+			last = i + 1
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	return append(parts, s[last:]), nil
+}
+
+func unquoteKV(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 {
+		switch {
+		case s[0] == '\'' && s[len(s)-1] == '\'':
+			return s[1 : len(s)-1]
+		case s[0] == '"' && s[len(s)-1] == '"':
+			var b strings.Builder
+			inner := s[1 : len(s)-1]
+			for i := 0; i < len(inner); i++ {
+				if inner[i] == '\\' && i+1 < len(inner) {
+					i++
+				}
+				b.WriteByte(inner[i])
+			}
+			return b.String()
+		}
+	}
+	return s
+}
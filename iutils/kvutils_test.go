@@ -0,0 +1,33 @@
+package iutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKV(t *testing.T) {
+	cases := []struct {
+		in, sep, kvSep string
+		want           map[string]string
+		ok             bool
+	}{
+		{"a=1,b=2", "", "", map[string]string{"a": "1", "b": "2"}, true},
+		{" a = 1 , b = 2 ", "", "", map[string]string{"a": "1", "b": "2"}, true},
+		{"a=1=2", "", "", map[string]string{"a": "1=2"}, true},
+		{"flag,a=1", "", "", map[string]string{"flag": "", "a": "1"}, true},
+		{`header="a=1,b=2",ua='x y'`, "", "", map[string]string{"header": "a=1,b=2", "ua": "x y"}, true},
+		{`a="say \"hi\""`, "", "", map[string]string{"a": `say "hi"`}, true},
+		{"msg=it's,b=2", "", "", map[string]string{"msg": "it's", "b": "2"}, true},
+		{"a:1;b:2", ";", ":", map[string]string{"a": "1", "b": "2"}, true},
+		{"a=1,,b=2,", "", "", map[string]string{"a": "1", "b": "2"}, true},
+		{"", "", "", map[string]string{}, true},
+		{"=1", "", "", nil, false},
+		{`a="1,b=2`, "", "", nil, false},
+	}
+	for _, c := range cases {
+		got, err := ParseKV(c.in, c.sep, c.kvSep)
+		if (err == nil) != c.ok || (c.ok && !reflect.DeepEqual(got, c.want)) {
+			t.Errorf("ParseKV(%q, %q, %q) = %v, %v; want %v, ok=%v", c.in, c.sep, c.kvSep, got, err, c.want, c.ok)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package iutils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter 令牌桶限速器, rps为每秒生成的令牌数, burst为桶的容量
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter rps<=0 时不限速
+func NewLimiter(rps float64, burst int) *Limiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Allow 立即尝试获取一个令牌, 不阻塞
+func (l *Limiter) Allow() bool {
+	if l.rate <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if l.tokens >= 1 {
+		l.tokens--
+		return true
+	}
+	return false
+}
+
+// Wait 阻塞直到获取一个令牌或ctx被取消
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	l.refill(time.Now())
+	// 预定一个令牌, 令牌数可以为负, 表示排队等待中的请求
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// 归还预定的令牌
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// KeyedLimiter 按key分别限速, 例如对每个host单独限速
+type KeyedLimiter struct {
+	mu       sync.Mutex
+	rps      float64
+	burst    int
+	limiters map[string]*Limiter
+}
+
+func NewKeyedLimiter(rps float64, burst int) *KeyedLimiter {
+	return &KeyedLimiter{
+		rps:      rps,
+		burst:    burst,
+		limiters: make(map[string]*Limiter),
+	}
+}
+
+func (kl *KeyedLimiter) Get(key string) *Limiter {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+	l, ok := kl.limiters[key]
+	if !ok {
+		l = NewLimiter(kl.rps, kl.burst)
+		kl.limiters[key] = l
+	}
+	return l
+}
+
+func (kl *KeyedLimiter) Allow(key string) bool {
+	return kl.Get(key).Allow()
+}
+
+func (kl *KeyedLimiter) Wait(ctx context.Context, key string) error {
+	return kl.Get(key).Wait(ctx)
+}
+
+// Remove 删除key对应的限速器, 避免扫描大量目标时内存持续增长
+func (kl *KeyedLimiter) Remove(key string) {
+	kl.mu.Lock()
+	delete(kl.limiters, key)
+	kl.mu.Unlock()
+}
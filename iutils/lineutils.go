@@ -0,0 +1,96 @@
+package iutils
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// LineReader 按行读取任意大小的输入, 兼容 \r\n 与 \n, 去除开头的UTF-8 BOM
+// 与bufio.Scanner不同, 单行长度不受64KB的限制, 适合读取数GB的字典与目标文件
+type LineReader struct {
+	r     *bufio.Reader
+	line  []byte
+	err   error
+	first bool
+}
+
+func NewLineReader(r io.Reader) *LineReader {
+	return &LineReader{r: bufio.NewReaderSize(r, 64<<10), first: true}
+}
+
+// Next 读取下一行, 读取结束或出错时返回false
+func (lr *LineReader) Next() bool {
+	if lr.err != nil {
+		return false
+	}
+
+	lr.line = lr.line[:0]
+	for {
+		chunk, err := lr.r.ReadSlice('\n')
+		lr.line = append(lr.line, chunk...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			lr.err = err
+			if len(lr.line) == 0 {
+				return false
+			}
+		}
+		break
+	}
+
+	if lr.first {
+		lr.first = false
+		if len(lr.line) >= 3 && lr.line[0] == 0xef && lr.line[1] == 0xbb && lr.line[2] == 0xbf {
+			lr.line = lr.line[3:]
+		}
+	}
+	if n := len(lr.line); n > 0 && lr.line[n-1] == '\n' {
+		lr.line = lr.line[:n-1]
+	}
+	if n := len(lr.line); n > 0 && lr.line[n-1] == '\r' {
+		lr.line = lr.line[:n-1]
+	}
+	return true
+}
+
+// Text 返回当前行, 不包含换行符
+func (lr *LineReader) Text() string {
+	return string(lr.line)
+}
+
+// Bytes 返回当前行, 内容在下一次调用Next时会被覆盖
+func (lr *LineReader) Bytes() []byte {
+	return lr.line
+}
+
+// Err 返回读取过程中遇到的错误, 正常读取到结尾时返回nil
+func (lr *LineReader) Err() error {
+	if lr.err == io.EOF {
+		return nil
+	}
+	return lr.err
+}
+
+// ReadLines 逐行读取r并调用fn, fn返回false时停止读取
+func ReadLines(r io.Reader, fn func(line string) bool) error {
+	lr := NewLineReader(r)
+	for lr.Next() {
+		if !fn(lr.Text()) {
+			break
+		}
+	}
+	return lr.Err()
+}
+
+// ReadFileLines 逐行读取文件并调用fn, fn返回false时停止读取
+func ReadFileLines(filename string, fn func(line string) bool) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ReadLines(f, fn)
+}
@@ -0,0 +1,120 @@
+package iutils
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU 线程安全的LRU缓存, 用于DNS缓存, 指纹缓存与去重窗口
+type LRU[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	ll      *list.List
+	items   map[K]*list.Element
+	OnEvict func(key K, value V) // 元素被淘汰时的回调, 在持有锁时调用
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU size<=0 时不限制容量
+func NewLRU[K comparable, V any](size int) *LRU[K, V] {
+	return &LRU[K, V]{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[K]*list.Element),
+	}
+}
+
+// Set 写入缓存, 超过容量时淘汰最久未使用的元素, 返回是否发生了淘汰
+func (c *LRU[K, V]) Set(key K, value V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.ll.MoveToFront(e)
+		e.Value.(*lruEntry[K, V]).value = value
+		return false
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.size > 0 && c.ll.Len() > c.size {
+		c.removeElement(c.ll.Back())
+		return true
+	}
+	return false
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.ll.MoveToFront(e)
+		return e.Value.(*lruEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek 读取缓存但不更新使用顺序
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		return e.Value.(*lruEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items[key]
+	return ok
+}
+
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.removeElement(e)
+		return true
+	}
+	return false
+}
+
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Keys 按从新到旧的顺序返回所有key
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.ll.Len())
+	for e := c.ll.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*lruEntry[K, V]).key)
+	}
+	return keys
+}
+
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.ll.Front(); e != nil; e = c.ll.Front() {
+		c.removeElement(e)
+	}
+}
+
+func (c *LRU[K, V]) removeElement(e *list.Element) {
+	c.ll.Remove(e)
+	entry := e.Value.(*lruEntry[K, V])
+	delete(c.items, entry.key)
+	if c.OnEvict != nil {
+		c.OnEvict(entry.key, entry.value)
+	}
+}
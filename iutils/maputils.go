@@ -2,18 +2,78 @@ package iutils
 
 import (
 	"fmt"
+	"sort"
 )
 
-// MergeMaps merges two maps into a New map
-func MergeMaps(m1, m2 map[string]interface{}) map[string]interface{} {
-	m := make(map[string]interface{}, len(m1)+len(m2))
-	for k, v := range m1 {
-		m[k] = v
+// Ordered 可以使用 < 比较的类型, 用于需要稳定排序的泛型函数
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// MergeMaps merges maps into a New map, later maps override earlier ones
+func MergeMaps[K comparable, V any](ms ...map[K]V) map[K]V {
+	var size int
+	for _, m := range ms {
+		size += len(m)
+	}
+	merged := make(map[K]V, size)
+	for _, m := range ms {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// Keys 返回map的所有key, 顺序不固定
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	for k, v := range m2 {
-		m[k] = v
+	return keys
+}
+
+// SortedKeys 返回升序排列的key, 用于输出稳定的结果
+func SortedKeys[K Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// Values 返回map的所有value, 顺序不固定
+func Values[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// InvertMap 交换key与value, 多个key对应同一个value时保留排序最小的key, 保证结果稳定
+func InvertMap[K Ordered, V comparable](m map[K]V) map[V]K {
+	inverted := make(map[V]K, len(m))
+	for _, k := range SortedKeys(m) {
+		if _, ok := inverted[m[k]]; !ok {
+			inverted[m[k]] = k
+		}
+	}
+	return inverted
+}
+
+// InvertSliceMap 反转一对多的映射, 例如 name->ports 转为 port->names, 每个value下的key按升序排列
+func InvertSliceMap[K Ordered, V comparable](m map[K][]V) map[V][]K {
+	inverted := make(map[V][]K)
+	for _, k := range SortedKeys(m) {
+		for _, v := range m[k] {
+			inverted[v] = append(inverted[v], k)
+		}
 	}
-	return m
+	return inverted
 }
 
 // 转为字符串
@@ -0,0 +1,96 @@
+package iutils
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// MemoryUsage 某一时刻的内存使用情况
+type MemoryUsage struct {
+	HeapAlloc uint64 `json:"heap_alloc"`
+	InUse     uint64 `json:"in_use"` // 向系统申请且未归还的内存, 近似于RSS
+	Limit     uint64 `json:"limit"`
+}
+
+// Ratio 已使用内存占上限的比例
+func (u MemoryUsage) Ratio() float64 {
+	if u.Limit == 0 {
+		return 0
+	}
+	return float64(u.InUse) / float64(u.Limit)
+}
+
+// MemoryGuard 定期采样内存使用, 接近上限时触发GC并调用回调, 避免大规模扫描时被OOM kill
+type MemoryGuard struct {
+	Limit     uint64        // 内存上限, 为0时使用系统总内存
+	Threshold float64       // 触发比例, 默认0.9
+	Interval  time.Duration // 采样间隔, 默认1s
+	FreeOS    bool          // 触发时执行 debug.FreeOSMemory 将内存归还给系统
+	OnExceed  func(MemoryUsage)
+}
+
+func NewMemoryGuard(limit uint64, fn func(MemoryUsage)) *MemoryGuard {
+	return &MemoryGuard{
+		Limit:     limit,
+		Threshold: 0.9,
+		Interval:  time.Second,
+		FreeOS:    true,
+		OnExceed:  fn,
+	}
+}
+
+// Usage 采样当前内存使用
+func (g *MemoryGuard) Usage() MemoryUsage {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	limit := g.Limit
+	if limit == 0 {
+		limit = SysInfo().TotalMemory
+	}
+	return MemoryUsage{
+		HeapAlloc: ms.HeapAlloc,
+		InUse:     ms.Sys - ms.HeapReleased,
+		Limit:     limit,
+	}
+}
+
+// Check 采样一次, 超过阈值时释放内存并调用OnExceed, 返回是否超过阈值
+func (g *MemoryGuard) Check() bool {
+	threshold := g.Threshold
+	if threshold <= 0 {
+		threshold = 0.9
+	}
+	usage := g.Usage()
+	if usage.Ratio() < threshold {
+		return false
+	}
+	if g.FreeOS {
+		debug.FreeOSMemory()
+	}
+	if g.OnExceed != nil {
+		g.OnExceed(usage)
+	}
+	return true
+}
+
+// Start 在后台按Interval持续检查, 直到ctx结束
+func (g *MemoryGuard) Start(ctx context.Context) {
+	interval := g.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				g.Check()
+			}
+		}
+	}()
+}
@@ -0,0 +1,18 @@
+package iutils
+
+// Must 在err不为nil时panic, 否则返回v, 用于集中处理 "出错即panic" 的场景
+// 例如 Must(strconv.Atoi("1"))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Must2 与Must相同, 用于返回两个值与error的函数
+func Must2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
+	if err != nil {
+		panic(err)
+	}
+	return v1, v2
+}
@@ -0,0 +1,36 @@
+package iutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExpandPath 展开路径开头的 ~ 与其中的环境变量, 例如 "~/.config/x", "$HOME/x"
+func ExpandPath(path string) string {
+	path = ExpandEnv(path)
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~\\") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + path[1:]
+		}
+	}
+	return filepath.Clean(path)
+}
+
+// AbsFrom 以base为基准解析rel, rel为绝对路径时直接返回
+func AbsFrom(base, rel string) string {
+	rel = ExpandPath(rel)
+	if filepath.IsAbs(rel) {
+		return rel
+	}
+	base = ExpandPath(base)
+	if abs, err := filepath.Abs(base); err == nil {
+		base = abs
+	}
+	return filepath.Join(base, rel)
+}
+
+// ExcRelPath 以可执行文件所在目录为基准解析路径, 使数据文件在不同的安装位置下都能被找到
+func ExcRelPath(rel string) string {
+	return AbsFrom(GetExcPath(), rel)
+}
@@ -0,0 +1,87 @@
+package iutils
+
+import (
+	"context"
+	"sync"
+)
+
+// PoolFunc 由Pool并发调用的处理函数
+type PoolFunc[T, R any] func(ctx context.Context, input T) (R, error)
+
+// PoolResult 单个输入的处理结果
+type PoolResult[T, R any] struct {
+	Input  T
+	Output R
+	Err    error
+}
+
+// Pool 固定数量worker的协程池, 是扫描器中最核心的循环
+type Pool[T, R any] struct {
+	Size int
+	fn   PoolFunc[T, R]
+}
+
+func NewPool[T, R any](size int, fn PoolFunc[T, R]) *Pool[T, R] {
+	if size <= 0 {
+		size = 1
+	}
+	return &Pool[T, R]{Size: size, fn: fn}
+}
+
+// Run 启动Size个worker消费inputs, inputs关闭或ctx取消后, 返回的channel会在所有worker退出后关闭
+func (p *Pool[T, R]) Run(ctx context.Context, inputs <-chan T) <-chan *PoolResult[T, R] {
+	results := make(chan *PoolResult[T, R], p.Size)
+	var wg sync.WaitGroup
+	wg.Add(p.Size)
+	for i := 0; i < p.Size; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case input, ok := <-inputs:
+					if !ok {
+						return
+					}
+					output, err := p.fn(ctx, input)
+					select {
+					case results <- &PoolResult[T, R]{Input: input, Output: output, Err: err}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// RunSlice 处理inputs中的所有元素并收集结果, 结果顺序与inputs一致, ctx取消后未处理的元素对应的结果为nil
+func (p *Pool[T, R]) RunSlice(ctx context.Context, inputs []T) []*PoolResult[T, R] {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := range inputs {
+			select {
+			case ch <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	indexed := NewPool(p.Size, func(ctx context.Context, i int) (R, error) {
+		return p.fn(ctx, inputs[i])
+	})
+	results := make([]*PoolResult[T, R], len(inputs))
+	for res := range indexed.Run(ctx, ch) {
+		results[res.Input] = &PoolResult[T, R]{Input: inputs[res.Input], Output: res.Output, Err: res.Err}
+	}
+	return results
+}
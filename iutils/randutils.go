@@ -0,0 +1,105 @@
+package iutils
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	LowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	UpperLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits       = "0123456789"
+	Letters      = LowerLetters + UpperLetters
+	Alphanumeric = Letters + Digits
+	HexChars     = "0123456789abcdef"
+)
+
+var (
+	fastRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	fastRandMu sync.Mutex
+)
+
+// RandomBytes 使用crypto/rand生成n个随机字节, 用于canary, boundary等不可预测的值
+// 系统随机源不可用时返回error, 不会退化为可预测的math/rand, 需要时显式使用 FastRandomBytes
+func RandomBytes(n int) ([]byte, error) {
+	bs := make([]byte, n)
+	if _, err := crand.Read(bs); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// RandomHex 生成n个字符的随机hex字符串
+func RandomHex(n int) (string, error) {
+	bs, err := RandomBytes((n + 1) / 2)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bs)[:n], nil
+}
+
+// RandomString 使用crypto/rand从charset中生成长度为n的随机字符串, charset按字节处理, 为空时使用Alphanumeric
+func RandomString(n int, charset string) (string, error) {
+	if charset == "" {
+		charset = Alphanumeric
+	}
+	if len(charset) > 256 {
+		res := make([]byte, n)
+		max := big.NewInt(int64(len(charset)))
+		for i := range res {
+			idx, err := crand.Int(crand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			res[i] = charset[idx.Int64()]
+		}
+		return string(res), nil
+	}
+
+	// 拒绝采样, 避免取模带来的分布偏差
+	limit := 256 - 256%len(charset)
+	res := make([]byte, 0, n)
+	for len(res) < n {
+		bs, err := RandomBytes(n - len(res) + n/4 + 1)
+		if err != nil {
+			return "", err
+		}
+		for _, b := range bs {
+			if int(b) >= limit {
+				continue
+			}
+			res = append(res, charset[int(b)%len(charset)])
+			if len(res) == n {
+				break
+			}
+		}
+	}
+	return string(res), nil
+}
+
+// FastRandomBytes 使用math/rand生成随机字节, 速度更快, 但结果可预测
+func FastRandomBytes(n int) []byte {
+	bs := make([]byte, n)
+	fastRandMu.Lock()
+	fastRand.Read(bs)
+	fastRandMu.Unlock()
+	return bs
+}
+
+// FastRandomString 使用math/rand从charset中生成随机字符串, 速度更快, 但结果可预测
+func FastRandomString(n int, charset string) string {
+	if charset == "" {
+		charset = Alphanumeric
+	}
+	res := make([]byte, n)
+	fastRandMu.Lock()
+	for i := range res {
+		res[i] = charset[fastRand.Intn(len(charset))]
+	}
+	fastRandMu.Unlock()
+	return string(res)
+}
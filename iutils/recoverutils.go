@@ -0,0 +1,67 @@
+package iutils
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError 捕获到的panic及其调用栈
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n%s", e.Value, e.Stack)
+}
+
+var (
+	panicReporterMu sync.RWMutex
+	panicReporter   = func(e *PanicError) {
+		fmt.Fprintln(os.Stderr, "[-] "+e.Error())
+	}
+)
+
+// SetPanicReporter 注册全局的panic上报函数, 默认输出到stderr
+func SetPanicReporter(fn func(*PanicError)) {
+	panicReporterMu.Lock()
+	panicReporter = fn
+	panicReporterMu.Unlock()
+}
+
+// Recover 需要以 defer Recover(handler) 的形式调用, 捕获panic与调用栈后交给handler
+// handler为nil时交给全局reporter
+func Recover(handler func(*PanicError)) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	e := &PanicError{Value: r, Stack: debug.Stack()}
+	if handler == nil {
+		panicReporterMu.RLock()
+		handler = panicReporter
+		panicReporterMu.RUnlock()
+	}
+	if handler != nil {
+		handler(e)
+	}
+}
+
+// SafeGo 在新的goroutine中运行fn, panic会被捕获并上报, 不会导致整个进程退出
+func SafeGo(fn func()) {
+	go func() {
+		defer Recover(nil)
+		fn()
+	}()
+}
+
+// SafeCall 同步运行fn, 将panic转为 *PanicError 返回
+func SafeCall(fn func()) (err error) {
+	defer Recover(func(e *PanicError) {
+		err = e
+	})
+	fn()
+	return nil
+}
@@ -0,0 +1,205 @@
+package iutils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+)
+
+// Set 线程安全的集合, 替代随处可见的 map[string]struct{} + mutex, 零值可直接使用
+type Set[T comparable] struct {
+	mu    sync.RWMutex
+	items map[T]struct{}
+}
+
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{items: make(map[T]struct{}, len(items))}
+	s.Add(items...)
+	return s
+}
+
+func (s *Set[T]) Add(items ...T) {
+	s.mu.Lock()
+	s.lazyInit()
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+	s.mu.Unlock()
+}
+
+// lazyInit 使零值Set可以直接使用, 调用方需持有写锁
+func (s *Set[T]) lazyInit() {
+	if s.items == nil {
+		s.items = make(map[T]struct{})
+	}
+}
+
+// AddIfAbsent 不存在时添加并返回true, 可用于并发场景下的去重
+func (s *Set[T]) AddIfAbsent(item T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lazyInit()
+	if _, ok := s.items[item]; ok {
+		return false
+	}
+	s.items[item] = struct{}{}
+	return true
+}
+
+func (s *Set[T]) Has(item T) bool {
+	s.mu.RLock()
+	_, ok := s.items[item]
+	s.mu.RUnlock()
+	return ok
+}
+
+func (s *Set[T]) Delete(items ...T) {
+	s.mu.Lock()
+	for _, item := range items {
+		delete(s.items, item)
+	}
+	s.mu.Unlock()
+}
+
+func (s *Set[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Items 返回集合中的所有元素, 顺序不固定
+func (s *Set[T]) Items() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]T, 0, len(s.items))
+	for item := range s.items {
+		items = append(items, item)
+	}
+	return items
+}
+
+// Union 返回包含两个集合所有元素的新集合
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	res := NewSet(s.Items()...)
+	res.Add(other.Items()...)
+	return res
+}
+
+// Intersect 返回两个集合共有元素的新集合
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	res := NewSet[T]()
+	for _, item := range s.Items() {
+		if other.Has(item) {
+			res.Add(item)
+		}
+	}
+	return res
+}
+
+// ShardedSet 分片的集合, 按hash将元素分散到多个Set中, 降低高并发写入时的锁竞争
+type ShardedSet[T comparable] struct {
+	shards []*Set[T]
+	hasher func(T) uint32
+}
+
+// NewShardedSet shards<=0 时使用32个分片, 字符串与整数类型直接hash, 其他类型建议使用 NewShardedSetFunc
+func NewShardedSet[T comparable](shards int) *ShardedSet[T] {
+	return NewShardedSetFunc[T](shards, nil)
+}
+
+// NewShardedSetFunc 使用自定义的hasher决定元素所在的分片, hasher为nil时与 NewShardedSet 相同
+func NewShardedSetFunc[T comparable](shards int, hasher func(T) uint32) *ShardedSet[T] {
+	if shards <= 0 {
+		shards = 32
+	}
+	s := &ShardedSet[T]{shards: make([]*Set[T], shards), hasher: hasher}
+	for i := range s.shards {
+		s.shards[i] = NewSet[T]()
+	}
+	return s
+}
+
+func (s *ShardedSet[T]) shard(item T) *Set[T] {
+	var sum uint32
+	if s.hasher != nil {
+		sum = s.hasher(item)
+	} else {
+		sum = defaultHash(item)
+	}
+	return s.shards[sum%uint32(len(s.shards))]
+}
+
+// defaultHash 字符串与整数类型(包括以其为底层类型的自定义类型)直接hash, 其余类型退化为fmt格式化后hash
+func defaultHash(item interface{}) uint32 {
+	h := fnv.New32a()
+	var buf [8]byte
+	v := reflect.ValueOf(item)
+	switch v.Kind() {
+	case reflect.String:
+		h.Write([]byte(v.String()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Int()))
+		h.Write(buf[:])
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		binary.LittleEndian.PutUint64(buf[:], v.Uint())
+		h.Write(buf[:])
+	default:
+		fmt.Fprint(h, item)
+	}
+	return h.Sum32()
+}
+
+func (s *ShardedSet[T]) Add(items ...T) {
+	for _, item := range items {
+		s.shard(item).Add(item)
+	}
+}
+
+func (s *ShardedSet[T]) AddIfAbsent(item T) bool {
+	return s.shard(item).AddIfAbsent(item)
+}
+
+func (s *ShardedSet[T]) Has(item T) bool {
+	return s.shard(item).Has(item)
+}
+
+func (s *ShardedSet[T]) Delete(items ...T) {
+	for _, item := range items {
+		s.shard(item).Delete(item)
+	}
+}
+
+func (s *ShardedSet[T]) Len() int {
+	var n int
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+func (s *ShardedSet[T]) Items() []T {
+	items := make([]T, 0, s.Len())
+	for _, shard := range s.shards {
+		items = append(items, shard.Items()...)
+	}
+	return items
+}
+
+func (s *ShardedSet[T]) Union(other *ShardedSet[T]) *ShardedSet[T] {
+	res := NewShardedSetFunc[T](len(s.shards), s.hasher)
+	res.Add(s.Items()...)
+	res.Add(other.Items()...)
+	return res
+}
+
+func (s *ShardedSet[T]) Intersect(other *ShardedSet[T]) *ShardedSet[T] {
+	res := NewShardedSetFunc[T](len(s.shards), s.hasher)
+	for _, item := range s.Items() {
+		if other.Has(item) {
+			res.Add(item)
+		}
+	}
+	return res
+}
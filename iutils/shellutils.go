@@ -0,0 +1,105 @@
+package iutils
+
+import (
+	"runtime"
+	"strings"
+)
+
+// QuoteArg 按当前平台的规则引用单个参数
+func QuoteArg(arg string) string {
+	if runtime.GOOS == "windows" {
+		return QuoteWindowsArg(arg)
+	}
+	return QuotePosixArg(arg)
+}
+
+// QuoteCommand 按当前平台的规则拼接命令, 用于在报告中展示或构造远程执行的命令
+func QuoteCommand(name string, args ...string) string {
+	quote := QuotePosixArg
+	if runtime.GOOS == "windows" {
+		quote = QuoteWindowsArg
+	}
+	return joinQuoted(quote, name, args)
+}
+
+// QuotePosixCommand 使用sh的规则拼接命令
+func QuotePosixCommand(name string, args ...string) string {
+	return joinQuoted(QuotePosixArg, name, args)
+}
+
+// QuoteCmdCommand 使用cmd.exe的规则拼接命令
+func QuoteCmdCommand(name string, args ...string) string {
+	return joinQuoted(QuoteCmdArg, name, args)
+}
+
+func joinQuoted(quote func(string) string, name string, args []string) string {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, quote(name))
+	for _, arg := range args {
+		quoted = append(quoted, quote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// QuotePosixArg 使用单引号引用参数, 只包含安全字符时原样返回
+func QuotePosixArg(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	safe := true
+	for _, c := range arg {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("_@%+=:,./-", c)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
+
+// QuoteWindowsArg 按CommandLineToArgvW的规则引用参数, 与syscall.EscapeArg相同
+func QuoteWindowsArg(arg string) string {
+	if arg == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(arg, " \t\n\v\"") {
+		return arg
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	var slashes int
+	for i := 0; i < len(arg); i++ {
+		c := arg[i]
+		switch c {
+		case '\\':
+			slashes++
+		case '"':
+			// 引号前的反斜杠需要加倍, 再转义引号本身
+			b.WriteString(strings.Repeat(`\`, slashes+1))
+			slashes = 0
+		default:
+			slashes = 0
+		}
+		b.WriteByte(c)
+	}
+	// 结尾的反斜杠会转义闭合引号, 需要加倍
+	b.WriteString(strings.Repeat(`\`, slashes))
+	b.WriteByte('"')
+	return b.String()
+}
+
+// QuoteCmdArg 在QuoteWindowsArg的基础上使用 ^ 转义cmd.exe的元字符
+func QuoteCmdArg(arg string) string {
+	arg = QuoteWindowsArg(arg)
+	var b strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if strings.IndexByte(`()%!^"<>&|`, arg[i]) != -1 {
+			b.WriteByte('^')
+		}
+		b.WriteByte(arg[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,27 @@
+package iutils
+
+import "testing"
+
+func TestQuotePosixArg(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"", "''"},
+		{"abc", "abc"},
+		{"/usr/bin/env", "/usr/bin/env"},
+		{"a=b,c:d@e%f+g", "a=b,c:d@e%f+g"},
+		{"a b", "'a b'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+		{"`id`", "'`id`'"},
+		{"a;b|c&d", "'a;b|c&d'"},
+		{"*.txt", "'*.txt'"},
+		{"line\nbreak", "'line\nbreak'"},
+		{"中文", "'中文'"},
+	}
+	for _, c := range cases {
+		if got := QuotePosixArg(c.in); got != c.want {
+			t.Errorf("QuotePosixArg(%q) = %q; want %q", c.in, got, c.want)
+		}
+	}
+}
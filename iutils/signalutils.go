@@ -0,0 +1,93 @@
+package iutils
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// GracePeriod 收到信号后等待清理函数执行的最长时间, 超时后强制退出
+	GracePeriod = 5 * time.Second
+
+	shutdownHooks   []func()
+	shutdownMu      sync.Mutex
+	shutdownOnce    sync.Once
+	interruptSignal = []os.Signal{os.Interrupt, syscall.SIGTERM}
+)
+
+// OnExit 注册退出前执行的清理函数, 在Exit, Fatal或收到中断信号(需调用过OnInterrupt)时执行, 不会安装信号处理
+// main函数正常返回时不会执行, 需要时在main的末尾调用Exit(0)
+func OnExit(fn func()) {
+	shutdownMu.Lock()
+	shutdownHooks = append(shutdownHooks, fn)
+	shutdownMu.Unlock()
+}
+
+// OnInterrupt 注册收到SIGINT/SIGTERM时执行的清理函数, 例如刷新输出文件
+// 清理函数按注册的逆序执行, 执行完毕或超过GracePeriod后退出进程, 再次收到信号时立即退出
+func OnInterrupt(fn func()) {
+	OnExit(fn)
+	shutdownOnce.Do(func() {
+		ch := make(chan os.Signal, 2)
+		signal.Notify(ch, interruptSignal...)
+		go func() {
+			sig := <-ch
+			code := 130
+			if sig == syscall.SIGTERM {
+				code = 143
+			}
+
+			done := make(chan struct{})
+			go func() {
+				RunShutdownHooks()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-ch:
+			case <-time.After(GracePeriod):
+			}
+			os.Exit(code)
+		}()
+	})
+}
+
+// RunShutdownHooks 按注册的逆序执行所有清理函数, 每个函数只会被执行一次
+func RunShutdownHooks() {
+	shutdownMu.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownMu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		func() {
+			// 单个清理函数panic不影响其他清理函数
+			defer func() { recover() }()
+			hooks[i]()
+		}()
+	}
+}
+
+// Exit 执行通过OnExit/OnInterrupt注册的清理函数后以code退出进程
+func Exit(code int) {
+	RunShutdownHooks()
+	os.Exit(code)
+}
+
+// WaitForSignal 阻塞直到收到SIGINT/SIGTERM或ctx被取消, ctx取消时返回nil
+func WaitForSignal(ctx context.Context) os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, interruptSignal...)
+	defer signal.Stop(ch)
+
+	select {
+	case sig := <-ch:
+		return sig
+	case <-ctx.Done():
+		return nil
+	}
+}
@@ -0,0 +1,19 @@
+package iutils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 3, Levenshtein("kitten", "sitting"))
+	assert.Equal(t, 0, Levenshtein("", ""))
+	assert.Equal(t, 1, Levenshtein("中文标题", "中文标签"))
+}
+
+func TestJaroWinkler(t *testing.T) {
+	assert.InDelta(t, 0.944, Jaro("MARTHA", "MARHTA"), 0.001)
+	assert.InDelta(t, 0.961, JaroWinkler("MARTHA", "MARHTA"), 0.001)
+	assert.InDelta(t, 0.813, JaroWinkler("DIXON", "DICKSONX"), 0.001)
+	assert.Equal(t, 1.0, Similarity("404 not found", "404 not found"))
+}
@@ -0,0 +1,121 @@
+package iutils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var byteUnits = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+
+// HumanBytes 将字节数格式化为可读形式, 以1024为进制, 例如 1536 -> "1.5KB"
+func HumanBytes(size uint64) string {
+	if size < 1024 {
+		return strconv.FormatUint(size, 10) + "B"
+	}
+	return humanize(float64(size), 1024, byteUnits)
+}
+
+// humanize 按base逐级换算到合适的单位, 保留两位小数
+// 先按保留的精度舍入再判断是否进位, 避免出现 "1024KB", "1000k"
+func humanize(value, base float64, units []string) string {
+	var i int
+	for i < len(units)-1 && math.Abs(math.Round(value*100)/100) >= base {
+		value /= base
+		i++
+	}
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".") + units[i]
+}
+
+// ParseBytes 解析 "10MB", "1.5g", "512k", "100" 等形式的大小, 以1024为进制, 不带单位时视为字节
+func ParseBytes(s string) (uint64, error) {
+	raw := s
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	var i int
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+	num, unit := s[:i], strings.TrimSpace(s[i:])
+	if num == "" {
+		return 0, fmt.Errorf("invalid size %q", raw)
+	}
+	// 允许 K, KB, KiB 三种写法, 单独的 iB 不是合法单位
+	unit = strings.TrimSuffix(unit, "B")
+	if len(unit) == 2 && unit[1] == 'I' {
+		unit = unit[:1]
+	}
+	value, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", raw)
+	}
+
+	var multiplier float64 = 1
+	switch unit {
+	case "":
+	case "K":
+		multiplier = 1 << 10
+	case "M":
+		multiplier = 1 << 20
+	case "G":
+		multiplier = 1 << 30
+	case "T":
+		multiplier = 1 << 40
+	case "P":
+		multiplier = 1 << 50
+	case "E":
+		multiplier = 1 << 60
+	default:
+		return 0, fmt.Errorf("invalid size unit %q", raw)
+	}
+
+	value *= multiplier
+	if value >= 1<<64 {
+		return 0, fmt.Errorf("size %q overflows uint64", raw)
+	}
+	return uint64(value), nil
+}
+
+var numberUnits = []string{"", "k", "m", "g", "t", "p", "e"}
+
+// ParseHumanNumber 解析 "10k", "1.5M", "2g", "1,000", "-3k" 等形式的数字, 以1000为进制, 用于线程数, 速率等参数
+func ParseHumanNumber(s string) (int64, error) {
+	raw := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.Replace(s, ",", "", -1)
+	s = strings.Replace(s, "_", "", -1)
+	if s == "" {
+		return 0, fmt.Errorf("invalid number %q", raw)
+	}
+
+	var multiplier float64 = 1
+	for i := len(numberUnits) - 1; i > 0; i-- {
+		if strings.HasSuffix(s, numberUnits[i]) {
+			s = strings.TrimSpace(s[:len(s)-1])
+			multiplier = math.Pow(1000, float64(i))
+			break
+		}
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && multiplier == 1 {
+		return n, nil
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid number %q", raw)
+	}
+	value *= multiplier
+	if value >= 1<<63 || value < -(1<<63) {
+		return 0, fmt.Errorf("number %q overflows int64", raw)
+	}
+	return int64(value), nil
+}
+
+// HumanNumber 将数字格式化为带SI后缀的形式, 以1000为进制, 例如 1500 -> "1.5k"
+func HumanNumber(n int64) string {
+	if n > -1000 && n < 1000 {
+		return strconv.FormatInt(n, 10)
+	}
+	return humanize(float64(n), 1000, numberUnits)
+}
@@ -0,0 +1,61 @@
+package iutils
+
+import "testing"
+
+func TestParseBytes(t *testing.T) {
+	cases := []struct {
+		in   string
+		want uint64
+		ok   bool
+	}{
+		{"100", 100, true},
+		{"512k", 512 << 10, true},
+		{"10MB", 10 << 20, true},
+		{"1.5g", 3 << 29, true},
+		{"2KiB", 2 << 10, true},
+		{" 1 TB ", 1 << 40, true},
+		{"0", 0, true},
+		{"", 0, false},
+		{"MB", 0, false},
+		{"1iB", 0, false},
+		{"10x", 0, false},
+		{"1.2.3k", 0, false},
+		{"-1k", 0, false},
+		{"16E", 0, false},
+	}
+	for _, c := range cases {
+		got, err := ParseBytes(c.in)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("ParseBytes(%q) = %d, %v; want %d, ok=%v", c.in, got, err, c.want, c.ok)
+		}
+	}
+}
+
+func TestParseHumanNumber(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"100", 100, true},
+		{"10k", 10000, true},
+		{"1.5M", 1500000, true},
+		{"2g", 2000000000, true},
+		{"1,000", 1000, true},
+		{"1_000", 1000, true},
+		{"-3k", -3000, true},
+		{"9223372036854775807", 9223372036854775807, true},
+		{"", 0, false},
+		{"k", 0, false},
+		{"abc", 0, false},
+		{"nan", 0, false},
+		{"10e", 0, false},
+		{"10000e", 0, false},
+	}
+	for _, c := range cases {
+		got, err := ParseHumanNumber(c.in)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("ParseHumanNumber(%q) = %d, %v; want %d, ok=%v", c.in, got, err, c.want, c.ok)
+		}
+	}
+}
@@ -37,27 +37,112 @@ func StringsUnique(ss []string) []string {
 	return res
 }
 
+// Unique 切片去重, 通过反射支持任意类型的切片
+//
+// Deprecated: 使用泛型版本 UniqueOf.
 func Unique(data interface{}) interface{} {
 	inArr := reflect.ValueOf(data)
 	if inArr.Kind() != reflect.Slice && inArr.Kind() != reflect.Array {
 		return data
 	}
-
 	existMap := make(map[interface{}]bool)
 	outArr := reflect.MakeSlice(inArr.Type(), 0, inArr.Len())
-
 	for i := 0; i < inArr.Len(); i++ {
 		iVal := inArr.Index(i)
-
 		if _, ok := existMap[iVal.Interface()]; !ok {
 			outArr = reflect.Append(outArr, inArr.Index(i))
 			existMap[iVal.Interface()] = true
 		}
 	}
+	return outArr.Interface()
+}
 
+// Chunk 与Unique相同, 通过反射支持任意类型的切片, 返回值为对应类型的二维切片
+//
+// Deprecated: 使用泛型版本 ChunkOf.
+func Chunk(data interface{}, size int) interface{} {
+	inArr := reflect.ValueOf(data)
+	if inArr.Kind() != reflect.Slice && inArr.Kind() != reflect.Array {
+		return data
+	}
+	if size <= 0 {
+		size = inArr.Len()
+	}
+
+	outArr := reflect.MakeSlice(reflect.SliceOf(reflect.SliceOf(inArr.Type().Elem())), 0, 0)
+	for i := 0; i < inArr.Len(); i += size {
+		end := i + size
+		if end > inArr.Len() {
+			end = inArr.Len()
+		}
+		chunk := reflect.MakeSlice(reflect.SliceOf(inArr.Type().Elem()), end-i, end-i)
+		reflect.Copy(chunk, inArr.Slice(i, end))
+		outArr = reflect.Append(outArr, chunk)
+	}
+	return outArr.Interface()
+}
+
+// Intersect 返回同时存在于a与b中的元素, a与b需为相同类型的切片
+//
+// Deprecated: 使用泛型版本 IntersectOf.
+func Intersect(a, b interface{}) interface{} {
+	return reflectFilterByExist(a, b, true)
+}
+
+// Diff 返回存在于a但不存在于b中的元素, a与b需为相同类型的切片
+//
+// Deprecated: 使用泛型版本 DiffOf.
+func Diff(a, b interface{}) interface{} {
+	return reflectFilterByExist(a, b, false)
+}
+
+func reflectFilterByExist(a, b interface{}, exist bool) interface{} {
+	aArr, bArr := reflect.ValueOf(a), reflect.ValueOf(b)
+	if aArr.Kind() != reflect.Slice || bArr.Kind() != reflect.Slice {
+		return a
+	}
+
+	existMap := make(map[interface{}]bool, bArr.Len())
+	for i := 0; i < bArr.Len(); i++ {
+		existMap[bArr.Index(i).Interface()] = true
+	}
+
+	added := make(map[interface{}]bool)
+	outArr := reflect.MakeSlice(aArr.Type(), 0, 0)
+	for i := 0; i < aArr.Len(); i++ {
+		iVal := aArr.Index(i).Interface()
+		if existMap[iVal] != exist || added[iVal] {
+			continue
+		}
+		added[iVal] = true
+		outArr = reflect.Append(outArr, aArr.Index(i))
+	}
 	return outArr.Interface()
 }
 
+// StringsChunk 将切片按size切分为多个子切片, 最后一个子切片可能不足size
+func StringsChunk(ss []string, size int) [][]string {
+	return ChunkOf(ss, size)
+}
+
+// StringsIntersect 返回同时存在于a与b中的元素, 保持a中的顺序并去重
+func StringsIntersect(a, b []string) []string {
+	return IntersectOf(a, b)
+}
+
+// StringsDiff 返回存在于a但不存在于b中的元素, 保持a中的顺序并去重
+func StringsDiff(a, b []string) []string {
+	return DiffOf(a, b)
+}
+
+func StringsFilter(ss []string, fn func(string) bool) []string {
+	return FilterOf(ss, fn)
+}
+
+func StringsMap(ss []string, fn func(string) string) []string {
+	return MapOf(ss, fn)
+}
+
 func Str2uintlist(s string) []uint {
 	var ipps []uint
 	ss := strings.Split(s, ",")
@@ -81,3 +166,48 @@ func RandomChoice(choices []string) string {
 	}
 	return choices[rand.Intn(len(choices))]
 }
+
+// Choice 从切片中随机选择一个元素, 切片为空时返回零值
+func Choice[T any](s []T) T {
+	var zero T
+	if len(s) == 0 {
+		return zero
+	}
+	fastRandMu.Lock()
+	i := fastRand.Intn(len(s))
+	fastRandMu.Unlock()
+	return s[i]
+}
+
+// Sample 不放回地随机选择n个元素, 返回新的切片, n大于长度时返回全部元素的随机排列
+func Sample[T any](s []T, n int) []T {
+	if n > len(s) {
+		n = len(s)
+	}
+	if n <= 0 {
+		return []T{}
+	}
+	fastRandMu.Lock()
+	perm := fastRand.Perm(len(s))
+	fastRandMu.Unlock()
+
+	res := make([]T, n)
+	for i := 0; i < n; i++ {
+		res[i] = s[perm[i]]
+	}
+	return res
+}
+
+// Shuffle 原地打乱切片, seed不为0时结果可复现
+func Shuffle[T any](s []T, seed int64) {
+	swap := func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	}
+	if seed != 0 {
+		rand.New(rand.NewSource(seed)).Shuffle(len(s), swap)
+		return
+	}
+	fastRandMu.Lock()
+	fastRand.Shuffle(len(s), swap)
+	fastRandMu.Unlock()
+}
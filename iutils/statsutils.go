@@ -0,0 +1,120 @@
+package iutils
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats 以名称区分的原子计数器与仪表, 用于统计扫描进度(sent, alive, open, errors)
+type Stats struct {
+	mu       sync.RWMutex
+	start    time.Time
+	counters map[string]*int64
+	gauges   map[string]*int64
+}
+
+// StatsSnapshot Stats在某一时刻的快照
+type StatsSnapshot struct {
+	Time     time.Time        `json:"time"`
+	Elapsed  time.Duration    `json:"elapsed"`
+	Counters map[string]int64 `json:"counters"`
+	Gauges   map[string]int64 `json:"gauges"`
+}
+
+func NewStats() *Stats {
+	return &Stats{
+		start:    time.Now(),
+		counters: make(map[string]*int64),
+		gauges:   make(map[string]*int64),
+	}
+}
+
+func (s *Stats) value(m map[string]*int64, name string) *int64 {
+	s.mu.RLock()
+	v, ok := m[name]
+	s.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok = m[name]; !ok {
+		v = new(int64)
+		m[name] = v
+	}
+	return v
+}
+
+// Add 计数器增加delta, 返回增加后的值
+func (s *Stats) Add(name string, delta int64) int64 {
+	return atomic.AddInt64(s.value(s.counters, name), delta)
+}
+
+func (s *Stats) Inc(name string) int64 {
+	return s.Add(name, 1)
+}
+
+func (s *Stats) Counter(name string) int64 {
+	return atomic.LoadInt64(s.value(s.counters, name))
+}
+
+// SetGauge 设置仪表的当前值, 例如当前的并发数
+func (s *Stats) SetGauge(name string, value int64) {
+	atomic.StoreInt64(s.value(s.gauges, name), value)
+}
+
+// AddGauge 仪表增加delta, delta可以为负
+func (s *Stats) AddGauge(name string, delta int64) int64 {
+	return atomic.AddInt64(s.value(s.gauges, name), delta)
+}
+
+func (s *Stats) Gauge(name string) int64 {
+	return atomic.LoadInt64(s.value(s.gauges, name))
+}
+
+func (s *Stats) Snapshot() *StatsSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now()
+	snapshot := &StatsSnapshot{
+		Time:     now,
+		Elapsed:  now.Sub(s.start),
+		Counters: make(map[string]int64, len(s.counters)),
+		Gauges:   make(map[string]int64, len(s.gauges)),
+	}
+	for name, v := range s.counters {
+		snapshot.Counters[name] = atomic.LoadInt64(v)
+	}
+	for name, v := range s.gauges {
+		snapshot.Gauges[name] = atomic.LoadInt64(v)
+	}
+	return snapshot
+}
+
+// Report 每隔interval调用一次fn, 直到ctx被取消, 取消时会再调用一次以输出最终结果
+func (s *Stats) Report(ctx context.Context, interval time.Duration, fn func(*StatsSnapshot)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fn(s.Snapshot())
+			case <-ctx.Done():
+				fn(s.Snapshot())
+				return
+			}
+		}
+	}()
+}
+
+// Rate 计数器每秒的平均增长速度
+func (snapshot *StatsSnapshot) Rate(name string) float64 {
+	if snapshot.Elapsed <= 0 {
+		return 0
+	}
+	return float64(snapshot.Counters[name]) / snapshot.Elapsed.Seconds()
+}
@@ -81,3 +81,53 @@ func UTF8ConvertString(src string) string {
 func UTF8ConvertBytes(src []byte) []byte {
 	return []byte(UTF8ConvertString(string(src)))
 }
+
+// ContainsAny 判断s是否包含needles中的任意一个, 与strings.ContainsAny不同, needle为字符串而非字符
+func ContainsAny(s string, needles []string) bool {
+	for _, needle := range needles {
+		if strings.Contains(s, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func HasPrefixAny(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func HasSuffixAny(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func EqualFoldAny(s string, targets []string) bool {
+	for _, target := range targets {
+		if strings.EqualFold(s, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDigits 判断s是否非空且只包含ASCII数字
+func IsDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package iutils
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StructToMap 将结构体转为map, key取自tag(例如 "json"), tag为空时使用字段名
+// tag为 "-" 的字段与未导出字段会被忽略, 匿名嵌入的结构体会展开到上一层, 嵌套的结构体递归转换
+func StructToMap(v interface{}, tag string) map[string]interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	m := make(map[string]interface{})
+	structToMap(rv, tag, m)
+	return m
+}
+
+func structToMap(rv reflect.Value, tag string, m map[string]interface{}) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name := field.Name
+		var omitempty bool
+		if tag != "" {
+			if value, ok := field.Tag.Lookup(tag); ok {
+				parts := strings.Split(value, ",")
+				if parts[0] == "-" {
+					continue
+				}
+				if parts[0] != "" {
+					name = parts[0]
+				}
+				omitempty = StringsContains(parts[1:], "omitempty")
+			}
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && name == field.Name {
+			embedded := fv
+			for embedded.Kind() == reflect.Ptr && !embedded.IsNil() {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				structToMap(embedded, tag, m)
+				continue
+			}
+		}
+		if omitempty && isEmptyValue(fv) {
+			continue
+		}
+		m[name] = structFieldValue(fv, tag)
+	}
+}
+
+func structFieldValue(fv reflect.Value, tag string) interface{} {
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	if fv.Kind() == reflect.Struct && fv.CanInterface() {
+		if _, ok := fv.Interface().(fmt.Stringer); !ok {
+			nested := make(map[string]interface{})
+			structToMap(fv, tag, nested)
+			return nested
+		}
+	}
+	if !fv.CanInterface() {
+		return nil
+	}
+	return fv.Interface()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Flatten 将嵌套的map与切片展开为单层map, key使用sep连接, 例如 {"a": {"b": 1}} -> {"a.b": 1}
+// 用于CSV输出与模板渲染
+func Flatten(m map[string]interface{}, sep string) map[string]interface{} {
+	flat := make(map[string]interface{})
+	for k, v := range m {
+		flattenValue(k, v, sep, flat)
+	}
+	return flat
+}
+
+func flattenValue(prefix string, v interface{}, sep string, flat map[string]interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			flattenValue(prefix+sep+k, item, sep, flat)
+		}
+		return
+	case map[string]string:
+		for k, item := range value {
+			flat[prefix+sep+k] = item
+		}
+		return
+	case *OrderedMap:
+		for _, k := range value.Keys() {
+			item, _ := value.Get(k)
+			flattenValue(prefix+sep+k, item, sep, flat)
+		}
+		return
+	case []byte:
+		flat[prefix] = value
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			flattenValue(prefix+sep+strconv.Itoa(i), rv.Index(i).Interface(), sep, flat)
+		}
+		return
+	}
+	flat[prefix] = v
+}
@@ -0,0 +1,48 @@
+package iutils
+
+import (
+	"os"
+	"runtime"
+)
+
+// SystemInfo 系统资源快照, 无法获取的字段为0
+type SystemInfo struct {
+	CPUs        int        `json:"cpus"`
+	TotalMemory uint64     `json:"total_memory"`
+	FreeMemory  uint64     `json:"free_memory"`
+	Load        [3]float64 `json:"load"` // 1, 5, 15分钟平均负载, windows下不可用
+	OpenFds     int        `json:"open_fds"`
+	FdLimit     int        `json:"fd_limit"`
+}
+
+// FdUsage 已打开文件描述符占上限的比例, 用于在耗尽资源前提示
+func (info *SystemInfo) FdUsage() float64 {
+	if info.FdLimit <= 0 {
+		return 0
+	}
+	return float64(info.OpenFds) / float64(info.FdLimit)
+}
+
+// SysInfo 获取当前系统的资源快照, 用于自动调整并发数
+func SysInfo() *SystemInfo {
+	info := &SystemInfo{
+		CPUs:    runtime.NumCPU(),
+		FdLimit: GetFdLimit(),
+	}
+	fillSysInfo(info)
+	return info
+}
+
+// countFds 统计dir(/proc/self/fd, /dev/fd)中的文件描述符数量, 不包括读取该目录时打开的描述符
+func countFds(dir string) int {
+	f, err := os.Open(dir)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	names, err := f.Readdirnames(-1)
+	if err != nil || len(names) == 0 {
+		return 0
+	}
+	return len(names) - 1
+}
@@ -0,0 +1,31 @@
+package iutils
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/sys/unix"
+)
+
+func fillSysInfo(info *SystemInfo) {
+	if total, err := unix.SysctlUint64("hw.memsize"); err == nil {
+		info.TotalMemory = total
+	}
+	// 空闲页与可立即回收的推测页, 与 vm_stat 的 Pages free + Pages speculative 一致
+	if pageSize, err := unix.SysctlUint32("hw.pagesize"); err == nil {
+		free, _ := unix.SysctlUint32("vm.page_free_count")
+		speculative, _ := unix.SysctlUint32("vm.page_speculative_count")
+		info.FreeMemory = (uint64(free) + uint64(speculative)) * uint64(pageSize)
+	}
+
+	// struct loadavg { fixpt_t ldavg[3]; long fscale; }
+	if raw, err := unix.SysctlRaw("vm.loadavg"); err == nil && len(raw) >= 24 {
+		scale := float64(binary.LittleEndian.Uint64(raw[16:24]))
+		for i := range info.Load {
+			if scale > 0 {
+				info.Load[i] = float64(binary.LittleEndian.Uint32(raw[i*4:])) / scale
+			}
+		}
+	}
+
+	info.OpenFds = countFds("/dev/fd")
+}
@@ -0,0 +1,39 @@
+package iutils
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func fillSysInfo(info *SystemInfo) {
+	if f, err := os.Open("/proc/meminfo"); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 2 {
+				continue
+			}
+			// /proc/meminfo 中的单位为kB
+			value, _ := strconv.ParseUint(fields[1], 10, 64)
+			switch fields[0] {
+			case "MemTotal:":
+				info.TotalMemory = value * 1024
+			case "MemAvailable:":
+				info.FreeMemory = value * 1024
+			}
+		}
+		f.Close()
+	}
+
+	if content, err := ioutil.ReadFile("/proc/loadavg"); err == nil {
+		fields := strings.Fields(string(content))
+		for i := 0; i < len(info.Load) && i < len(fields); i++ {
+			info.Load[i], _ = strconv.ParseFloat(fields[i], 64)
+		}
+	}
+
+	info.OpenFds = countFds("/proc/self/fd")
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package iutils
+
+func fillSysInfo(info *SystemInfo) {
+	info.OpenFds = countFds("/dev/fd")
+}
@@ -0,0 +1,39 @@
+package iutils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	kernel32                 = windows.NewLazySystemDLL("kernel32.dll")
+	procGlobalMemoryStatusEx = kernel32.NewProc("GlobalMemoryStatusEx")
+	procGetProcessHandleCnt  = kernel32.NewProc("GetProcessHandleCount")
+)
+
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+func fillSysInfo(info *SystemInfo) {
+	status := memoryStatusEx{}
+	status.Length = uint32(unsafe.Sizeof(status))
+	if r, _, _ := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status))); r != 0 {
+		info.TotalMemory = status.TotalPhys
+		info.FreeMemory = status.AvailPhys
+	}
+
+	var count uint32
+	if r, _, _ := procGetProcessHandleCnt.Call(uintptr(windows.CurrentProcess()), uintptr(unsafe.Pointer(&count))); r != 0 {
+		info.OpenFds = int(count)
+	}
+}
@@ -2,6 +2,7 @@ package iutils
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -68,6 +69,7 @@ func Chtime(filename string) bool {
 	return true
 }
 
+// IsRoot 仅检查uid, windows下请使用 IsElevated
 func IsRoot() bool {
 	if os.Getuid() == 0 {
 		return true
@@ -75,17 +77,6 @@ func IsRoot() bool {
 	return false
 }
 
-func GetFdLimit() int {
-	cmd := exec.Command("sh", "-c", "ulimit -n")
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		println(err.Error())
-		return -1
-	}
-	s := strings.TrimSpace(string(out))
-	return ToInt(s)
-}
-
 func GetExcPath() string {
 	file, _ := exec.LookPath(os.Args[0])
 	// 获取包含可执行文件名称的路径
@@ -96,7 +87,43 @@ func GetExcPath() string {
 	return strings.Replace(ret, "\\", "/", -1) + "/"
 }
 
+// FatalHandler 控制Fatal的行为, 默认与旧版本一致: 输出到stdout并以0退出
+type FatalHandler struct {
+	ExitCode int
+	Output   io.Writer
+	// Panic 为true时以panic(*FatalError)代替os.Exit, 便于作为库使用时由调用方recover
+	// 此时进程并未退出, 因此不会执行OnExit注册的清理函数
+	Panic bool
+}
+
+// FatalError Panic模式下panic的值
+type FatalError struct {
+	Message string
+}
+
+func (e *FatalError) Error() string {
+	return e.Message
+}
+
+var DefaultFatalHandler = &FatalHandler{ExitCode: 0, Output: os.Stdout}
+
+// Fatal 输出错误信息, 执行通过OnExit/OnInterrupt注册的清理函数后退出, Panic模式下不执行清理函数直接panic
+func (h *FatalHandler) Fatal(s string) {
+	out := h.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	fmt.Fprintln(out, "[-] "+s)
+	if h.Panic {
+		panic(&FatalError{Message: s})
+	}
+	Exit(h.ExitCode)
+}
+
 func Fatal(s string) {
-	fmt.Println("[-] " + s)
-	os.Exit(0)
+	DefaultFatalHandler.Fatal(s)
+}
+
+func Fatalf(format string, args ...interface{}) {
+	DefaultFatalHandler.Fatal(fmt.Sprintf(format, args...))
 }
@@ -0,0 +1,35 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package iutils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"strings"
+)
+
+// GetFdLimit 获取当前进程可打开文件描述符的软限制, 失败时返回-1, 无限制时返回 math.MaxInt32
+func GetFdLimit() int {
+	out, _, _, err := RunCommand(context.Background(), "sh", "-c", "ulimit -n")
+	if err != nil {
+		return -1
+	}
+	limit := strings.TrimSpace(string(out))
+	if limit == "unlimited" {
+		return math.MaxInt32
+	}
+	return ToInt(limit)
+}
+
+// SetFdLimit 当前平台不支持
+func SetFdLimit(n uint64) error {
+	return errors.New("SetFdLimit is not supported on this platform")
+}
+
+// IsElevated 检查当前进程是否拥有root权限
+func IsElevated() bool {
+	return os.Geteuid() == 0
+}
@@ -0,0 +1,51 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package iutils
+
+import (
+	"math"
+	"os"
+	"syscall"
+)
+
+// GetFdLimit 获取当前进程可打开文件描述符的软限制, 失败时返回-1, 无限制(RLIM_INFINITY)时返回 math.MaxInt32
+func GetFdLimit() int {
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return -1
+	}
+	// RLIM_INFINITY 为全1, 直接转换为int会得到-1, 与失败无法区分
+	if uint64(rlimit.Cur) > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(rlimit.Cur)
+}
+
+// SetFdLimit 将文件描述符的软限制提高到n, 超过硬限制时会尝试同时提高硬限制(需要root权限)
+func SetFdLimit(n uint64) error {
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return err
+	}
+	if uint64(rlimit.Cur) >= n {
+		return nil
+	}
+
+	if uint64(rlimit.Max) < n {
+		raised := rlimit
+		raised.Cur, raised.Max = n, n
+		if err := syscall.Setrlimit(syscall.RLIMIT_NOFILE, &raised); err == nil {
+			return nil
+		}
+		// 无权限提高硬限制, 退而求其次提高到硬限制
+		n = uint64(rlimit.Max)
+	}
+	rlimit.Cur = n
+	return syscall.Setrlimit(syscall.RLIMIT_NOFILE, &rlimit)
+}
+
+// IsElevated 检查当前进程是否拥有root权限
+func IsElevated() bool {
+	return os.Geteuid() == 0
+}
@@ -0,0 +1,50 @@
+//go:build windows
+// +build windows
+
+package iutils
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// windows 没有类似 ulimit 的文件描述符软限制, 内核的句柄表限制单个进程最多持有 2^24 (16,777,216) 个句柄
+// 这是固定的上限而不是可查询的配置, 实际可打开的数量通常先受分页池内存限制
+const windowsHandleLimit = 1 << 24
+
+// GetFdLimit 返回windows下进程可持有的句柄上限
+func GetFdLimit() int {
+	return windowsHandleLimit
+}
+
+// SetFdLimit windows下句柄数量无需调整, 仅在超过系统上限时返回错误
+func SetFdLimit(n uint64) error {
+	if n > windowsHandleLimit {
+		return fmt.Errorf("fd limit %d exceeds windows handle limit %d", n, windowsHandleLimit)
+	}
+	return nil
+}
+
+// IsElevated 检查当前进程的token是否属于Administrators组, UAC未提升时返回false
+func IsElevated() bool {
+	var sid *windows.SID
+	err := windows.AllocateAndInitializeSid(
+		&windows.SECURITY_NT_AUTHORITY,
+		2,
+		windows.SECURITY_BUILTIN_DOMAIN_RID,
+		windows.DOMAIN_ALIAS_RID_ADMINS,
+		0, 0, 0, 0, 0, 0,
+		&sid)
+	if err != nil {
+		return false
+	}
+	defer windows.FreeSid(sid)
+
+	// token为0时, CheckTokenMembership 使用当前线程的模拟token
+	member, err := windows.Token(0).IsMember(sid)
+	if err != nil {
+		return false
+	}
+	return member
+}
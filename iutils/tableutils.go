@@ -0,0 +1,174 @@
+package iutils
+
+import (
+	"io"
+	"strings"
+)
+
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+)
+
+// Table 简单的ASCII表格, 按显示宽度对齐, 中文内容不会导致错位
+type Table struct {
+	Header   []string
+	Rows     [][]string
+	Align    []Alignment // 每列的对齐方式, 未设置的列左对齐
+	MaxWidth int         // 单元格的最大显示宽度, 超过时换行, 为0时不限制
+}
+
+func NewTable(header ...string) *Table {
+	return &Table{Header: header}
+}
+
+func (t *Table) Append(row ...string) {
+	t.Rows = append(t.Rows, row)
+}
+
+func (t *Table) String() string {
+	var b strings.Builder
+	_ = t.Render(&b)
+	return b.String()
+}
+
+// Render 将表格写入w
+func (t *Table) Render(w io.Writer) error {
+	columns := len(t.Header)
+	for _, row := range t.Rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return nil
+	}
+
+	var header [][]string
+	if len(t.Header) > 0 {
+		header = t.wrapRow(t.Header, columns)
+	}
+	rows := make([][][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		rows[i] = t.wrapRow(row, columns)
+	}
+
+	widths := make([]int, columns)
+	for _, row := range append([][][]string{header}, rows...) {
+		for i, cell := range row {
+			for _, line := range cell {
+				if lw := StringWidth(line); lw > widths[i] {
+					widths[i] = lw
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	separator := tableSeparator(widths)
+	b.WriteString(separator)
+	if header != nil {
+		t.writeRow(&b, header, widths)
+		b.WriteString(separator)
+	}
+	for _, row := range rows {
+		t.writeRow(&b, row, widths)
+	}
+	if len(rows) > 0 {
+		b.WriteString(separator)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// 将一行中的每个单元格拆分为多行
+func (t *Table) wrapRow(row []string, columns int) [][]string {
+	cells := make([][]string, columns)
+	for i := range cells {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		cells[i] = WrapWidth(cell, t.MaxWidth)
+	}
+	return cells
+}
+
+func (t *Table) writeRow(b *strings.Builder, row [][]string, widths []int) {
+	var height int
+	for _, cell := range row {
+		if len(cell) > height {
+			height = len(cell)
+		}
+	}
+
+	for line := 0; line < height; line++ {
+		b.WriteString("|")
+		for i, cell := range row {
+			var s string
+			if line < len(cell) {
+				s = cell[line]
+			}
+			b.WriteString(" ")
+			b.WriteString(t.alignCell(s, widths[i], i))
+			b.WriteString(" |")
+		}
+		b.WriteString("\n")
+	}
+}
+
+func (t *Table) alignCell(s string, w, column int) string {
+	align := AlignLeft
+	if column < len(t.Align) {
+		align = t.Align[column]
+	}
+	switch align {
+	case AlignRight:
+		return PadLeftWidth(s, w)
+	case AlignCenter:
+		left := (w - StringWidth(s)) / 2
+		return PadWidth(padding(left)+s, w)
+	default:
+		return PadWidth(s, w)
+	}
+}
+
+func tableSeparator(widths []int) string {
+	var b strings.Builder
+	b.WriteString("+")
+	for _, w := range widths {
+		b.WriteString(strings.Repeat("-", w+2))
+		b.WriteString("+")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// WrapWidth 按显示宽度将字符串拆分为多行, 同时会在原有的换行符处拆分, w<=0时仅按换行符拆分
+func WrapWidth(s string, w int) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n") {
+		if w <= 0 || StringWidth(line) <= w {
+			lines = append(lines, line)
+			continue
+		}
+
+		var cur strings.Builder
+		var curWidth int
+		for _, r := range line {
+			rw := RuneWidth(r)
+			if curWidth+rw > w && curWidth > 0 {
+				lines = append(lines, cur.String())
+				cur.Reset()
+				curWidth = 0
+			}
+			cur.WriteRune(r)
+			curWidth += rw
+		}
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
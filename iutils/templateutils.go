@@ -0,0 +1,257 @@
+package iutils
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TemplateFunc 模板中可调用的函数
+type TemplateFunc func(args ...string) (string, error)
+
+// TemplateMaxSize 模板函数生成内容的最大字节数, 与encode中DSL操作符的上限一致, 防止模板中的长度参数导致分配过大的内存
+var TemplateMaxSize = 64 << 20
+
+var (
+	templateFuncs   = make(map[string]TemplateFunc)
+	templateFuncsMu sync.RWMutex
+)
+
+func init() {
+	RegisterTemplateFunc("randstr", func(args ...string) (string, error) {
+		n, err := templateLengthArg("randstr", args, 0, 8, 1)
+		if err != nil {
+			return "", err
+		}
+		var charset string
+		if len(args) > 1 {
+			charset = args[1]
+		}
+		return RandomString(n, charset)
+	})
+	RegisterTemplateFunc("randhex", func(args ...string) (string, error) {
+		n, err := templateLengthArg("randhex", args, 0, 8, 1)
+		if err != nil {
+			return "", err
+		}
+		return RandomHex(n)
+	})
+	RegisterTemplateFunc("randint", func(args ...string) (string, error) {
+		low, err := templateIntArg(args, 0, 0)
+		if err != nil {
+			return "", err
+		}
+		high, err := templateIntArg(args, 1, 100)
+		if err != nil {
+			return "", err
+		}
+		if high < low {
+			return "", fmt.Errorf("randint: max %d < min %d", high, low)
+		}
+		return strconv.Itoa(low + rand.Intn(high-low+1)), nil
+	})
+	RegisterTemplateFunc("env", func(args ...string) (string, error) {
+		if len(args) == 0 {
+			return "", fmt.Errorf("env: missing variable name")
+		}
+		if v, ok := os.LookupEnv(args[0]); ok {
+			return v, nil
+		}
+		if len(args) > 1 {
+			return args[1], nil
+		}
+		return "", nil
+	})
+	RegisterTemplateFunc("upper", func(args ...string) (string, error) {
+		return strings.ToUpper(strings.Join(args, "")), nil
+	})
+	RegisterTemplateFunc("lower", func(args ...string) (string, error) {
+		return strings.ToLower(strings.Join(args, "")), nil
+	})
+	RegisterTemplateFunc("repeat", func(args ...string) (string, error) {
+		if len(args) == 0 {
+			return "", fmt.Errorf("repeat: usage repeat(s, n)")
+		}
+		n, err := templateLengthArg("repeat", args, 1, 1, len(args[0]))
+		if err != nil {
+			return "", err
+		}
+		return strings.Repeat(args[0], n), nil
+	})
+}
+
+// RegisterTemplateFunc 注册模板函数, 同名函数会被覆盖
+// 导入encode包后, 其中的DSL操作符也会被注册为模板函数
+func RegisterTemplateFunc(name string, fn TemplateFunc) {
+	templateFuncsMu.Lock()
+	templateFuncs[name] = fn
+	templateFuncsMu.Unlock()
+}
+
+func GetTemplateFunc(name string) (TemplateFunc, bool) {
+	templateFuncsMu.RLock()
+	defer templateFuncsMu.RUnlock()
+	fn, ok := templateFuncs[name]
+	return fn, ok
+}
+
+// RenderTemplate 渲染 {{func(arg, ...)}} 形式的模板, 参数可以是字面量, 带引号的字符串或嵌套调用
+// 例如 "id={{randhex(16)}}&sign={{md5(randstr(8))}}"
+func RenderTemplate(s string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "{{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(s[start:], "}}")
+		if end == -1 {
+			break
+		}
+		end += start
+
+		p := &templateParser{s: strings.TrimSpace(s[start+2 : end])}
+		value, err := p.parseCall()
+		if err != nil {
+			return "", err
+		}
+		if p.skipSpace(); p.pos != len(p.s) {
+			return "", fmt.Errorf("unexpected %q in template %q", p.s[p.pos:], p.s)
+		}
+		b.WriteString(s[:start])
+		b.WriteString(value)
+		s = s[end+2:]
+	}
+	b.WriteString(s)
+	return b.String(), nil
+}
+
+type templateParser struct {
+	s   string
+	pos int
+}
+
+func (p *templateParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *templateParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *templateParser) readIdent() string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			p.pos++
+		} else {
+			break
+		}
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *templateParser) parseCall() (string, error) {
+	p.skipSpace()
+	name := p.readIdent()
+	if name == "" {
+		return "", fmt.Errorf("missing function name in template %q", p.s)
+	}
+	fn, ok := GetTemplateFunc(name)
+	if !ok {
+		return "", fmt.Errorf("unknown template function %s", name)
+	}
+
+	var args []string
+	p.skipSpace()
+	if p.peek() == '(' {
+		p.pos++
+		for {
+			p.skipSpace()
+			if p.peek() == ')' {
+				p.pos++
+				break
+			}
+			arg, err := p.parseArg()
+			if err != nil {
+				return "", err
+			}
+			args = append(args, arg)
+			p.skipSpace()
+			switch p.peek() {
+			case ',':
+				p.pos++
+			case ')':
+			default:
+				return "", fmt.Errorf("unterminated arguments of %s in template %q", name, p.s)
+			}
+		}
+	}
+	return fn(args...)
+}
+
+func (p *templateParser) parseArg() (string, error) {
+	switch quote := p.peek(); quote {
+	case '"', '\'':
+		end := p.pos + 1
+		for end < len(p.s) && p.s[end] != quote {
+			if p.s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.s) {
+			return "", fmt.Errorf("unterminated string in template %q", p.s)
+		}
+		raw := p.s[p.pos+1 : end]
+		p.pos = end + 1
+		if quote == '"' {
+			return strconv.Unquote(`"` + raw + `"`)
+		}
+		return raw, nil
+	}
+
+	// 标识符后紧跟括号时视为嵌套调用, 否则作为字面量读取到 ',' 或 ')'
+	start := p.pos
+	p.readIdent()
+	p.skipSpace()
+	if p.peek() == '(' && p.pos > start {
+		p.pos = start
+		return p.parseCall()
+	}
+	for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != ')' {
+		p.pos++
+	}
+	return strings.TrimSpace(p.s[start:p.pos]), nil
+}
+
+func templateIntArg(args []string, i, def int) (int, error) {
+	if len(args) <= i || args[i] == "" {
+		return def, nil
+	}
+	return strconv.Atoi(args[i])
+}
+
+// templateLengthArg 读取长度参数, 拒绝负数以及生成内容(n*unit字节)超过 TemplateMaxSize 的情况
+func templateLengthArg(name string, args []string, i, def, unit int) (int, error) {
+	n, err := templateIntArg(args, i, def)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", name, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%s: invalid length %d", name, n)
+	}
+	if unit > 0 && n > TemplateMaxSize/unit {
+		return 0, fmt.Errorf("%s: length %d exceeds limit %d", name, n, TemplateMaxSize)
+	}
+	return n, nil
+}
@@ -0,0 +1,45 @@
+package iutils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// IsTTY 判断fd是否为终端, 例如 IsTTY(os.Stdout.Fd())
+func IsTTY(fd uintptr) bool {
+	return term.IsTerminal(int(fd))
+}
+
+// SupportsColor 判断stdout是否适合输出ANSI颜色
+// 遵循 NO_COLOR 与 FORCE_COLOR 约定, 输出被重定向到文件或管道时返回false
+func SupportsColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if v, ok := os.LookupEnv("FORCE_COLOR"); ok && v != "0" {
+		return true
+	}
+	if strings.ToLower(os.Getenv("TERM")) == "dumb" {
+		return false
+	}
+	if !IsTTY(os.Stdout.Fd()) {
+		return false
+	}
+	return enableVirtualTerminal(os.Stdout.Fd())
+}
+
+// TerminalWidth 返回终端的列数, 无法获取时依次尝试 COLUMNS 环境变量与默认值80
+func TerminalWidth() int {
+	for _, f := range []*os.File{os.Stdout, os.Stderr, os.Stdin} {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+// +build !windows
+
+package iutils
+
+func enableVirtualTerminal(fd uintptr) bool {
+	return true
+}
@@ -0,0 +1,18 @@
+package iutils
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal 开启控制台的ANSI转义序列支持, 旧版本windows不支持时返回false
+func enableVirtualTerminal(fd uintptr) bool {
+	var mode uint32
+	handle := windows.Handle(fd)
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
@@ -0,0 +1,122 @@
+package iutils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// ParseDuration 在time.ParseDuration的基础上支持 d(天) 与 w(周), 例如 "1d", "2w", "1h30m", "1d12h"
+// 不带单位的纯数字视为秒
+func ParseDuration(s string) (time.Duration, error) {
+	raw := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", raw)
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return floatDuration(n*float64(time.Second), raw)
+	}
+
+	var neg bool
+	if s[0] == '-' || s[0] == '+' {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	var d float64
+	for s != "" {
+		var i int
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+		s = s[i:]
+
+		i = 0
+		for i < len(s) && s[i] != '.' && (s[i] < '0' || s[i] > '9') {
+			i++
+		}
+		unit, ok := durationUnits[s[:i]]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q in duration %q", s[:i], raw)
+		}
+		s = s[i:]
+		d += n * float64(unit)
+	}
+
+	if neg {
+		d = -d
+	}
+	return floatDuration(d, raw)
+}
+
+// floatDuration 将纳秒数转换为Duration, 拒绝inf, nan与超出int64范围的值, 与time.ParseDuration一致
+func floatDuration(ns float64, raw string) (time.Duration, error) {
+	if math.IsNaN(ns) || math.IsInf(ns, 0) {
+		return 0, fmt.Errorf("invalid duration %q", raw)
+	}
+	if ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return 0, fmt.Errorf("invalid duration %q: out of range", raw)
+	}
+	return time.Duration(ns), nil
+}
+
+// 时间格式预设, 保证不同工具的结果文件名与报告头一致
+const (
+	TimeCompact  = "compact"  // 20060102150405
+	TimeISO      = "iso"      // 2006-01-02T15:04:05Z07:00
+	TimeFilename = "filename" // 2006-01-02_15-04-05, 可以安全地用于文件名
+	TimeDate     = "date"     // 2006-01-02
+	TimeDateTime = "datetime" // 2006-01-02 15:04:05
+)
+
+var TimeLayouts = map[string]string{
+	TimeCompact:  "20060102150405",
+	TimeISO:      time.RFC3339,
+	TimeFilename: "2006-01-02_15-04-05",
+	TimeDate:     "2006-01-02",
+	TimeDateTime: "2006-01-02 15:04:05",
+}
+
+// timeLayout 预设名称不存在时将name本身视为layout
+func timeLayout(name string) string {
+	if layout, ok := TimeLayouts[name]; ok {
+		return layout
+	}
+	return name
+}
+
+// NowString 以预设格式输出当前时间, 例如 NowString(TimeFilename)
+func NowString(name string) string {
+	return FormatTime(time.Now(), name)
+}
+
+func FormatTime(t time.Time, name string) string {
+	return t.Format(timeLayout(name))
+}
+
+// ParseTime 以预设格式解析时间, 不带时区的格式按本地时区解析
+func ParseTime(s, name string) (time.Time, error) {
+	return time.ParseInLocation(timeLayout(name), strings.TrimSpace(s), time.Local)
+}
@@ -0,0 +1,37 @@
+package iutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"30", 30 * time.Second, true},
+		{"1.5", 1500 * time.Millisecond, true},
+		{"500ms", 500 * time.Millisecond, true},
+		{"1h30m", 90 * time.Minute, true},
+		{"1d", 24 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"1d12h", 36 * time.Hour, true},
+		{"1.5D", 36 * time.Hour, true},
+		{"-1h", -time.Hour, true},
+		{"10us", 10 * time.Microsecond, true},
+		{"", 0, false},
+		{"h", 0, false},
+		{"1y", 0, false},
+		{"1h-", 0, false},
+		{"1..5s", 0, false},
+		{"100000000w", 0, false},
+	}
+	for _, c := range cases {
+		got, err := ParseDuration(c.in)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v, ok=%v", c.in, got, err, c.want, c.ok)
+		}
+	}
+}
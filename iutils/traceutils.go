@@ -0,0 +1,27 @@
+package iutils
+
+import (
+	"os"
+)
+
+// CopyTimes 将ref的修改时间同时设置为dst的访问与修改时间, 使落地文件与参照文件看起来同时生成
+func CopyTimes(dst, ref string) error {
+	info, err := os.Stat(ref)
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+// DeleteSelfOnExit 注册退出钩子, 只在通过 Exit, Fatal(非Panic模式) 或 OnInterrupt 的信号处理退出时删除当前可执行文件
+// main函数正常返回或Fatal以Panic模式被调用方recover时不会删除
+func DeleteSelfOnExit() error {
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	OnExit(func() {
+		_ = removeSelf(path)
+	})
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package iutils
+
+import (
+	"os"
+)
+
+// ClearXattrs 当前平台不支持, 直接返回nil
+func ClearXattrs(path string) error {
+	return nil
+}
+
+func removeSelf(path string) error {
+	return os.Remove(path)
+}
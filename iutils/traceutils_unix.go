@@ -0,0 +1,38 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package iutils
+
+import (
+	"bytes"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// ClearXattrs 删除文件的全部扩展属性, 如 macOS 的 com.apple.quarantine
+func ClearXattrs(path string) error {
+	size, err := unix.Listxattr(path, nil)
+	if err != nil || size <= 0 {
+		return err
+	}
+	buf := make([]byte, size)
+	size, err = unix.Listxattr(path, buf)
+	if err != nil {
+		return err
+	}
+	for _, name := range bytes.Split(buf[:size], []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+		if err := unix.Removexattr(path, string(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unix下删除正在运行的可执行文件不影响进程本身
+func removeSelf(path string) error {
+	return os.Remove(path)
+}
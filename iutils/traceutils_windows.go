@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+package iutils
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// ClearXattrs 删除文件的 Zone.Identifier 备用数据流(网络下载标记), 流不存在时返回nil
+func ClearXattrs(path string) error {
+	err := os.Remove(path + ":Zone.Identifier")
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// windows下无法删除正在运行的可执行文件, 交由延迟执行的cmd在进程退出后删除
+func removeSelf(path string) error {
+	cmd := exec.Command("cmd.exe")
+	// cmd不识别Go默认的参数转义, 直接指定完整命令行
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow: true,
+		CmdLine:    `cmd.exe /c ping -n 3 127.0.0.1 >nul & del /f /q "` + path + `"`,
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,112 @@
+package iutils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type UACategory string
+
+const (
+	UADesktop UACategory = "desktop"
+	UAMobile  UACategory = "mobile"
+	UACrawler UACategory = "crawler"
+)
+
+var (
+	uaMu   sync.RWMutex
+	uaPool = map[UACategory][]string{
+		UADesktop: {
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.0.0",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Safari/605.1.15",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 14.4; rv:125.0) Gecko/20100101 Firefox/125.0",
+			"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+		},
+		UAMobile: {
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Mobile/15E148 Safari/604.1",
+			"Mozilla/5.0 (iPad; CPU OS 17_4_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Mobile/15E148 Safari/604.1",
+			"Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+			"Mozilla/5.0 (Linux; Android 14; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+			"Mozilla/5.0 (Android 14; Mobile; rv:125.0) Gecko/125.0 Firefox/125.0",
+		},
+		UACrawler: {
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			"Mozilla/5.0 (compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm)",
+			"Mozilla/5.0 (compatible; Baiduspider/2.0; +http://www.baidu.com/search/spider.html)",
+			"Mozilla/5.0 (compatible; YandexBot/3.0; +http://yandex.com/bots)",
+			"Sogou web spider/4.0(+http://www.sogou.com/docs/help/webmasters.htm#07)",
+		},
+	}
+)
+
+// RandomUA 从桌面与移动端UA中随机选择一个, 不包含爬虫UA
+func RandomUA() string {
+	return RandomUAOf(UADesktop, UAMobile)
+}
+
+// RandomUAOf 从指定分类中随机选择一个UA, 分类为空时从全部分类中选择
+func RandomUAOf(categories ...UACategory) string {
+	uas := UserAgents(categories...)
+	if len(uas) == 0 {
+		return ""
+	}
+	fastRandMu.Lock()
+	i := fastRand.Intn(len(uas))
+	fastRandMu.Unlock()
+	return uas[i]
+}
+
+// UserAgents 返回指定分类的UA副本, 分类为空时返回全部
+func UserAgents(categories ...UACategory) []string {
+	uaMu.RLock()
+	defer uaMu.RUnlock()
+	if len(categories) == 0 {
+		for c := range uaPool {
+			categories = append(categories, c)
+		}
+	}
+	var uas []string
+	for _, c := range categories {
+		uas = append(uas, uaPool[c]...)
+	}
+	return uas
+}
+
+// SetUserAgents 替换指定分类的UA列表, uas为空时删除该分类
+func SetUserAgents(category UACategory, uas []string) {
+	uaMu.Lock()
+	defer uaMu.Unlock()
+	if len(uas) == 0 {
+		delete(uaPool, category)
+		return
+	}
+	uaPool[category] = append([]string(nil), uas...)
+}
+
+// RefreshUserAgents 每隔interval调用fetch更新UA池, 直到ctx结束
+// fetch返回错误或空结果时保留原有列表
+func RefreshUserAgents(ctx context.Context, interval time.Duration, fetch func() (map[UACategory][]string, error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if uas, err := fetch(); err == nil {
+				for c, list := range uas {
+					if len(list) > 0 {
+						SetUserAgents(c, list)
+					}
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,111 @@
+package iutils
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// 严格模式要求输入已是规范形式; Lenient模式会先去除空白, 将全角字符转为半角,
+// 并容忍常见的手工输入习惯(如IPv6外的方括号, 省略scheme的URL, 带前导零的端口)
+
+// IsIP 判断s是否为合法的IPv4/IPv6地址
+func IsIP(s string) bool {
+	return net.ParseIP(s) != nil
+}
+
+// IsIPLenient 宽松判断IP, 允许 [::1] 形式
+func IsIPLenient(s string) bool {
+	s = lenientInput(s)
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		s = s[1 : len(s)-1]
+	}
+	return IsIP(s)
+}
+
+// IsCIDR 判断s是否为合法的CIDR, 如 10.0.0.0/8
+func IsCIDR(s string) bool {
+	_, _, err := net.ParseCIDR(s)
+	return err == nil
+}
+
+// IsCIDRLenient 宽松判断CIDR, 不带掩码的IP也视为CIDR
+func IsCIDRLenient(s string) bool {
+	s = lenientInput(s)
+	if !strings.Contains(s, "/") {
+		return IsIPLenient(s)
+	}
+	return IsCIDR(s)
+}
+
+// IsDomain 判断s是否为合法的域名, 至少包含一个点, 且顶级域不为纯数字
+func IsDomain(s string) bool {
+	if s == "" || IsIP(s) || !strings.Contains(s, ".") {
+		return false
+	}
+	host, err := NormalizeHost(s)
+	if err != nil || host != strings.ToLower(s) {
+		return false
+	}
+	for _, r := range host {
+		if r >= 0x80 {
+			return false
+		}
+	}
+	return !IsDigits(host[strings.LastIndex(host, ".")+1:])
+}
+
+// IsDomainLenient 宽松判断域名, 允许全角字符, 结尾的点, 国际化域名与 localhost 这类单label主机名
+func IsDomainLenient(s string) bool {
+	host, err := NormalizeHost(lenientInput(s))
+	if err != nil || IsIP(host) {
+		return false
+	}
+	return !IsDigits(host[strings.LastIndex(host, ".")+1:])
+}
+
+// IsURL 判断s是否为带scheme与host的完整URL
+func IsURL(s string) bool {
+	if strings.TrimSpace(s) != s {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return false
+	}
+	host := u.Hostname()
+	return IsIP(host) || IsDomainLenient(host)
+}
+
+// IsURLLenient 宽松判断URL, 缺省scheme时按 http:// 补全
+func IsURLLenient(s string) bool {
+	s = lenientInput(s)
+	if !strings.Contains(s, "://") {
+		s = "http://" + strings.TrimPrefix(s, "//")
+	}
+	return IsURL(s)
+}
+
+// IsPort 判断s是否为 1-65535 的端口, 不允许前导零
+func IsPort(s string) bool {
+	if s == "" || s[0] == '0' || !IsDigits(s) {
+		return false
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n <= 65535
+}
+
+// IsPortLenient 宽松判断端口, 允许前导零与端口0
+func IsPortLenient(s string) bool {
+	s = lenientInput(s)
+	if !IsDigits(s) {
+		return false
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n <= 65535
+}
+
+func lenientInput(s string) string {
+	return strings.TrimSpace(FoldWidth(s))
+}
@@ -0,0 +1,63 @@
+package iutils
+
+import "testing"
+
+func TestIsDomain(t *testing.T) {
+	cases := []struct {
+		in              string
+		strict, lenient bool
+	}{
+		{"example.com", true, true},
+		{"a.b-c.example.co.uk", true, true},
+		{"Example.com", true, true},
+		{"example.com.", false, true},
+		{" example.com", false, true},
+		{"ｅｘａｍｐｌｅ．ｃｏｍ", false, true},
+		{"例子.测试", false, true},
+		{"localhost", false, true},
+		{"1.2.3.4", false, false},
+		{"1.2.3.999", false, false},
+		{"example", false, true},
+		{"", false, false},
+		{"-a.com", false, false},
+		{"a_b.com", true, true},
+		{"http://example.com", false, false},
+	}
+	for _, c := range cases {
+		if got := IsDomain(c.in); got != c.strict {
+			t.Errorf("IsDomain(%q) = %v; want %v", c.in, got, c.strict)
+		}
+		if got := IsDomainLenient(c.in); got != c.lenient {
+			t.Errorf("IsDomainLenient(%q) = %v; want %v", c.in, got, c.lenient)
+		}
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	cases := []struct {
+		in              string
+		strict, lenient bool
+	}{
+		{"http://example.com", true, true},
+		{"https://example.com:8443/path?q=1", true, true},
+		{"http://1.2.3.4/", true, true},
+		{"http://[::1]:8080/", true, true},
+		{"http://localhost", true, true},
+		{"example.com", false, true},
+		{"//example.com/a", false, true},
+		{"1.2.3.4:80", false, true},
+		{" http://example.com", false, true},
+		{"http://", false, false},
+		{"http:///path", false, false},
+		{"http://exa mple.com", false, false},
+		{"", false, false},
+	}
+	for _, c := range cases {
+		if got := IsURL(c.in); got != c.strict {
+			t.Errorf("IsURL(%q) = %v; want %v", c.in, got, c.strict)
+		}
+		if got := IsURLLenient(c.in); got != c.lenient {
+			t.Errorf("IsURLLenient(%q) = %v; want %v", c.in, got, c.lenient)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package iutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// FoldWidth 规范化从各处复制粘贴来的输入: 全角字符转为半角, 中文句号转为 '.', 并去除BOM与无效的UTF-8
+// 用于清洗目标, 主机名与宽松校验之前
+func FoldWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '。':
+			return '.'
+		case '\ufeff', utf8.RuneError:
+			return -1
+		}
+		return r
+	}, width.Fold.String(s))
+}
+
+// RuneWidth 返回rune在终端中的显示宽度, 中日韩等宽字符为2, 组合字符与控制字符为0
+func RuneWidth(r rune) int {
+	if r == 0 || unicode.Is(unicode.Mn, r) || unicode.IsControl(r) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// StringWidth 返回字符串在终端中的显示宽度
+func StringWidth(s string) int {
+	var w int
+	for _, r := range s {
+		w += RuneWidth(r)
+	}
+	return w
+}
+
+// Truncate 按rune截断字符串, 截断时追加ellipsis, 结果(含ellipsis)不超过n个rune
+func Truncate(s string, n int, ellipsis string) string {
+	if n <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	n -= utf8.RuneCountInString(ellipsis)
+	if n <= 0 {
+		return string([]rune(ellipsis)[:n+utf8.RuneCountInString(ellipsis)])
+	}
+	return string([]rune(s)[:n]) + ellipsis
+}
+
+// TruncateWidth 按显示宽度截断字符串, 避免中文banner导致表格错位
+func TruncateWidth(s string, w int, ellipsis string) string {
+	if w <= 0 {
+		return ""
+	}
+	if StringWidth(s) <= w {
+		return s
+	}
+	w -= StringWidth(ellipsis)
+	if w < 0 {
+		return TruncateWidth(ellipsis, w+StringWidth(ellipsis), "")
+	}
+
+	var b strings.Builder
+	var cur int
+	for _, r := range s {
+		rw := RuneWidth(r)
+		if cur+rw > w {
+			break
+		}
+		cur += rw
+		b.WriteRune(r)
+	}
+	return b.String() + ellipsis
+}
+
+// Pad 在右侧填充空格至n个rune
+func Pad(s string, n int) string {
+	return s + padding(n-utf8.RuneCountInString(s))
+}
+
+// PadLeft 在左侧填充空格至n个rune
+func PadLeft(s string, n int) string {
+	return padding(n-utf8.RuneCountInString(s)) + s
+}
+
+// PadWidth 在右侧填充空格至显示宽度w
+func PadWidth(s string, w int) string {
+	return s + padding(w-StringWidth(s))
+}
+
+// PadLeftWidth 在左侧填充空格至显示宽度w
+func PadLeftWidth(s string, w int) string {
+	return padding(w-StringWidth(s)) + s
+}
+
+func padding(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat(" ", n)
+}
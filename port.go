@@ -2,7 +2,6 @@ package utils
 
 import (
 	"fmt"
-	"github.com/chainreactors/utils/iutils"
 	"strconv"
 	"strings"
 )
@@ -112,59 +111,161 @@ func (preset PortPreset) ParsePortSlice(ports []string) []string {
 		}
 	}
 
-	portSlice = expandPorts(portSlice)
-	excludePorts = expandPorts(excludePorts)
-	portSlice = removeExcludedPorts(portSlice, excludePorts)
+	excludes := expandPortList(excludePorts)
+	var excluded, seen portSet
+	excludedNames := make(map[string]struct{})
+	for _, item := range excludes.items {
+		if item >= 0 {
+			excluded.add(item)
+		} else {
+			excludedNames[excludes.name(item)] = struct{}{}
+		}
+	}
 
-	return iutils.StringsUnique(portSlice)
+	// 排除与去重在数字层面完成, 只在最后转换为字符串
+	includes := expandPortList(portSlice)
+	result := &portList{items: make([]int32, 0, len(includes.items)), names: includes.names}
+	seenNames := make(map[string]struct{})
+	for _, item := range includes.items {
+		if item >= 0 {
+			if excluded.has(item) || seen.has(item) {
+				continue
+			}
+			seen.add(item)
+		} else {
+			name := includes.name(item)
+			if _, ok := excludedNames[name]; ok {
+				continue
+			}
+			if _, ok := seenNames[name]; ok {
+				continue
+			}
+			seenNames[name] = struct{}{}
+		}
+		result.items = append(result.items, item)
+	}
+	return result.Strings()
 }
 
-func removeExcludedPorts(portSlice, excludePorts []string) []string {
-	excludeMap := make(map[string]struct{})
-	for _, port := range excludePorts {
-		excludeMap[port] = struct{}{}
+// 将string格式的port range 转为单个port组成的slice
+func expandPorts(ports []string) []string {
+	return expandPortList(ports).Strings()
+}
+
+// portList 以数字形式保存展开后的端口
+// 非数字的端口名(例如icmp, winrm)保存在names中, 在items中以负数下标引用
+type portList struct {
+	items []int32
+	names []string
+}
+
+func (pl *portList) name(item int32) string {
+	return pl.names[-item-1]
+}
+
+// Strings 一次性将所有端口转换为字符串, 数字端口共享同一块底层内存
+func (pl *portList) Strings() []string {
+	buf := make([]byte, 0, len(pl.items)*5)
+	ends := make([]int, len(pl.items))
+	for i, item := range pl.items {
+		if item >= 0 {
+			buf = strconv.AppendInt(buf, int64(item), 10)
+		}
+		ends[i] = len(buf)
 	}
 
-	var result []string
-	for _, port := range portSlice {
-		if _, found := excludeMap[port]; !found {
-			result = append(result, port)
+	joined := string(buf)
+	ss := make([]string, len(pl.items))
+	var last int
+	for i, item := range pl.items {
+		if item >= 0 {
+			ss[i] = joined[last:ends[i]]
+		} else {
+			ss[i] = pl.name(item)
 		}
+		last = ends[i]
 	}
-	return result
+	return ss
 }
 
-// 将string格式的port range 转为单个port组成的slice
-func expandPorts(ports []string) []string {
-	var tmpports []string
+// portSet 65536位的bitmap, 用于端口去重与排除
+type portSet [1024]uint64
+
+func (s *portSet) add(port int32) {
+	s[port>>6] |= 1 << uint(port&63)
+}
+
+func (s *portSet) has(port int32) bool {
+	return s[port>>6]&(1<<uint(port&63)) != 0
+}
+
+// 将string格式的port range 转为数字端口组成的portList
+func expandPortList(ports []string) *portList {
+	type portRange struct {
+		start, end int32
+		name       string
+	}
+
+	ranges := make([]portRange, 0, len(ports))
+	var total int
 	for _, pr := range ports {
+		pr = strings.TrimSpace(pr)
 		if len(pr) == 0 {
 			continue
 		}
-		pr = strings.TrimSpace(pr)
-		if pr[0] == '-' {
-			pr = "1" + pr
+		if start, end, ok := parsePortRange(pr); ok {
+			ranges = append(ranges, portRange{start: start, end: end})
+			if end >= start {
+				total += int(end-start) + 1
+			}
+		} else {
+			ranges = append(ranges, portRange{start: -1, name: pr})
+			total++
+		}
+	}
+
+	pl := &portList{items: make([]int32, 0, total)}
+	for _, r := range ranges {
+		if r.start < 0 {
+			pl.names = append(pl.names, r.name)
+			pl.items = append(pl.items, int32(-len(pl.names)))
+			continue
 		}
-		if pr[len(pr)-1] == '-' {
-			pr = pr + "65535"
+		for port := r.start; port <= r.end; port++ {
+			pl.items = append(pl.items, port)
 		}
-		tmpports = append(tmpports, expandPort(pr)...)
 	}
-	return tmpports
+	return pl
 }
 
-// 将string格式的port range 转为单个port组成的slice
-func expandPort(port string) []string {
-	var tmpports []string
-	if strings.Contains(port, "-") {
-		sf := strings.Split(port, "-")
-		start, _ := strconv.Atoi(sf[0])
-		fin, _ := strconv.Atoi(sf[1])
-		for port := start; port <= fin; port++ {
-			tmpports = append(tmpports, strconv.Itoa(port))
+// 解析单个端口或端口范围, "-100" 与 "100-" 分别表示 1-100 与 100-65535
+func parsePortRange(pr string) (start, end int32, ok bool) {
+	if pr[0] == '-' {
+		pr = "1" + pr
+	}
+	if pr[len(pr)-1] == '-' {
+		pr = pr + "65535"
+	}
+
+	i := strings.Index(pr, "-")
+	if i == -1 {
+		port, err := strconv.Atoi(pr)
+		if err != nil || port < 0 || port > 65535 {
+			return 0, 0, false
 		}
-	} else {
-		tmpports = append(tmpports, port)
+		return int32(port), int32(port), true
+	}
+
+	s, err := strconv.Atoi(pr[:i])
+	if err != nil || s < 0 || s > 65535 {
+		return 0, 0, false
+	}
+	e, err := strconv.Atoi(pr[i+1:])
+	if err != nil || e < 0 {
+		return 0, 0, false
+	}
+	if e > 65535 {
+		e = 65535
 	}
-	return tmpports
+	return int32(s), int32(e), true
 }
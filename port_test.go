@@ -64,3 +64,15 @@ func TestParsePortString(t *testing.T) {
 		assert.ElementsMatch(t, tc.expected, actual)
 	}
 }
+
+func TestExpandPorts(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3", "icmp", "65534", "65535"}, expandPorts([]string{"-3", "icmp", "65534-"}))
+	assert.Equal(t, 65535, len(expandPorts([]string{"1-65535"})))
+}
+
+func BenchmarkExpandPorts(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		expandPorts([]string{"1-65535"})
+	}
+}
@@ -1,19 +1,66 @@
 package utils
 
 import (
-	"net/url"
+	"net"
 	"strings"
+
+	"github.com/chainreactors/utils/iutils"
 )
 
+// ParseHost 从目标中提取 host, 会先经过 CleanTarget 清洗, 并去除端口
 func ParseHost(target string) string {
+	host, _ := SplitTarget(target)
+	return host
+}
+
+// CleanTarget 清洗从各处复制粘贴来的不规范目标
+// 例如 "https://1.2.3.4:8443/login", "1.2.3.4,", "host。com", 以及首尾的空白与BOM
+// 返回值仅保留 host[:port], 不带scheme的IP的CIDR掩码会被保留
+func CleanTarget(target string) string {
+	target = iutils.FoldWidth(target)
 	target = strings.TrimSpace(target)
-	if strings.Contains(target, "http") {
-		u, err := url.Parse(target)
-		if err != nil {
-			return ""
+	target = strings.Trim(target, ",;'\"`")
+	target = strings.TrimSpace(target)
+
+	isURL := false
+	if i := strings.Index(target, "://"); i != -1 {
+		target, isURL = target[i+3:], true
+	} else if strings.HasPrefix(target, "//") {
+		target, isURL = target[2:], true
+	}
+
+	if i := strings.IndexAny(target, "?#"); i != -1 {
+		target = target[:i]
+	}
+	if i := strings.Index(target, "/"); i != -1 {
+		// 只有不带scheme的IP才保留CIDR掩码, URL中的 /8, /2024 等都是路径
+		if isURL || !iutils.IsDigits(target[i+1:]) || net.ParseIP(target[:i]) == nil {
+			target = target[:i]
+		}
+	}
+	if i := strings.LastIndex(target, "@"); i != -1 {
+		target = target[i+1:]
+	}
+
+	target = strings.TrimRight(target, ".")
+	return strings.ToLower(target)
+}
+
+// SplitTarget 将目标清洗后拆分为 host 与 port, 不带端口时 port 为空
+func SplitTarget(target string) (host, port string) {
+	target = CleanTarget(target)
+	if strings.HasPrefix(target, "[") {
+		if h, p, err := net.SplitHostPort(target); err == nil {
+			return h, p
+		}
+		return strings.Trim(target, "[]"), ""
+	}
+
+	if strings.Count(target, ":") == 1 {
+		i := strings.Index(target, ":")
+		if iutils.IsDigits(target[i+1:]) {
+			return target[:i], target[i+1:]
 		}
-		return u.Hostname()
-	} else {
-		return strings.TrimSpace(strings.Trim(target, "/"))
 	}
+	return target, ""
 }